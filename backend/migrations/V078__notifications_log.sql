@@ -0,0 +1,34 @@
+-- ============================================================================
+-- V078: Notification Audit Log
+-- ============================================================================
+-- Purpose: Record every notification processed by notification-service
+--          (payload, channel, delivery status, trace_id) for auditing and
+--          a future delivery-status API.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS notifications_log (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id     TEXT,
+    user_id       TEXT,
+    channel       TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    error         TEXT,
+    payload       JSONB NOT NULL,
+    trace_id      TEXT,
+    received_at   TIMESTAMPTZ NOT NULL,
+    processed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_log_tenant
+    ON notifications_log(tenant_id, processed_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_log_user
+    ON notifications_log(user_id, processed_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_log_trace
+    ON notifications_log(trace_id) WHERE trace_id IS NOT NULL;
+
+COMMENT ON TABLE notifications_log IS
+    'Audit trail of notifications processed by notification-service';
+COMMENT ON COLUMN notifications_log.status IS
+    'sent | failed | skipped | rate_limited';
@@ -0,0 +1,94 @@
+// Package grpcmw berisi unary interceptor logging + metrics untuk gRPC server.
+// Modul bersama: dipakai flow-executor, notification-service dan visualhoop-compiler
+// (inventory_service) lewat replace ke backend/pkg/grpcmw.
+package grpcmw
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var ServerHandled = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total RPCs completed on the server, by service, method and status code",
+	},
+	[]string{"grpc_service", "grpc_method", "grpc_code"},
+)
+
+var ServerHandlingSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of RPCs handled by the server",
+		Buckets: prometheus.DefBuckets,
+	},
+	[]string{"grpc_service", "grpc_method"},
+)
+
+var registerOnce sync.Once
+
+// RegisterMetrics aman dipanggil berkali-kali (mis. beberapa server dalam satu proses)
+func RegisterMetrics() {
+	registerOnce.Do(func() {
+		prometheus.MustRegister(ServerHandled)
+		prometheus.MustRegister(ServerHandlingSeconds)
+	})
+}
+
+// UnaryServerInterceptors mengembalikan ServerOption berisi chain logging + metrics
+func UnaryServerInterceptors(logger zerolog.Logger) grpc.ServerOption {
+	RegisterMetrics()
+	return grpc.ChainUnaryInterceptor(
+		LoggingUnaryInterceptor(logger),
+		MetricsUnaryInterceptor(),
+	)
+}
+
+// LoggingUnaryInterceptor mencatat method, durasi dan status code setiap RPC
+func LoggingUnaryInterceptor(logger zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		code := status.Code(err)
+
+		event := logger.Info()
+		if err != nil {
+			event = logger.Warn().Err(err)
+		}
+		event.
+			Str("grpc_method", info.FullMethod).
+			Str("grpc_code", code.String()).
+			Dur("duration", time.Since(start)).
+			Msg("📡 gRPC request handled")
+		return resp, err
+	}
+}
+
+// MetricsUnaryInterceptor mengisi grpc_server_handled_total dan grpc_server_handling_seconds
+func MetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		service, method := splitMethod(info.FullMethod)
+		ServerHandlingSeconds.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		ServerHandled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+		return resp, err
+	}
+}
+
+// splitMethod memecah "/package.Service/Method" menjadi service dan method
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
@@ -0,0 +1,21 @@
+package grpcmw
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// RegisterReflection mengaktifkan gRPC reflection (untuk grpcurl) hanya jika GRPC_REFLECTION=true.
+// Default mati supaya skema service tidak ter-expose di prod.
+func RegisterReflection(s *grpc.Server, logger zerolog.Logger) {
+	enabled, _ := strconv.ParseBool(os.Getenv("GRPC_REFLECTION"))
+	if !enabled {
+		return
+	}
+	reflection.Register(s)
+	logger.Warn().Msg("🪞 gRPC reflection enabled (GRPC_REFLECTION=true), jangan aktifkan di prod")
+}
@@ -3,11 +3,16 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -17,36 +22,104 @@ import (
 
 	"github.com/milkyhoop/flow-executor/internal/delivery"
 	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/flowwatch"
+	"github.com/milkyhoop/flow-executor/internal/kafkautil"
+	"github.com/milkyhoop/flow-executor/internal/loader"
 	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+	"github.com/milkyhoop/flow-executor/internal/scheduler"
+	"github.com/milkyhoop/flow-executor/internal/statestore"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
 	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
 func main() {
+	// --selftest: jalankan SELFTEST_FLOW sekali lalu exit (non-zero jika gagal), tanpa HTTP server
+	selfTestOnly := flag.Bool("selftest", false, "run SELFTEST_FLOW once and exit")
+	flag.Parse()
+
 	// Load .env dari root (optional, tidak error jika tidak ada)
 	_ = godotenv.Load("../../../.env")
 
 	// Inisialisasi logger zerolog
 	utils.InitLogger("flow-executor")
 
-	// Inisialisasi Kafka writer
-	delivery.InitKafkaWriter()
+	// Inisialisasi Kafka writer; SASL/TLS invalid → gagal start, jangan diam-diam plaintext
+	if err := delivery.InitKafkaWriter(); err != nil {
+		utils.Log.Fatal().Err(err).Msg("❌ Invalid Kafka SASL/TLS config")
+	}
+
+	// Tujuan event node dari engine: NOTIFICATION_SINK=kafka|webhook|none
+	sink, err := delivery.NotificationSinkFromEnv()
+	if err != nil {
+		utils.Log.Fatal().Err(err).Msg("❌ Invalid notification sink config")
+	}
+	observer.SetNotificationSink(sink)
+
+	// Writer observer untuk event ber-topic sendiri (flow-completed), broker dari KAFKA_BROKER(S)
+	if err := observer.InitKafkaWriter(kafkautil.Brokers()); err != nil {
+		utils.Log.Fatal().Err(err).Msg("❌ Invalid Kafka SASL/TLS config")
+	}
 
 	utils.Log.Info().Msg("🚀 Flow Executor MilkyHoop Started")
 
 	// Register Prometheus metrics
 	observer.RegisterMetrics()
 
+	// Tracing OTLP (run-flow → flow → node → gRPC call), aktif jika OTEL_EXPORTER_OTLP_ENDPOINT di-set
+	tracing.InitFromEnv()
+
+	// Checkpoint store untuk resume flow (STATE_STORE=redis), default noop
+	store := statestore.FromEnv()
+	executor.SetStateStore(store)
+
+	// Hasil Idempotency-Key disimpan di store yang sama (Redis), fallback in-memory
+	delivery.SetResultStore(statestore.ResultStoreFor(store))
+
+	// Node LogComplaint dikirim ke complaint_service via gRPC
+	executor.SetComplaintLogger(delivery.LogComplaintWithMeta)
+
+	// Validasi tenant via TenantManager sebelum flow jalan (matikan dengan TENANT_VALIDATION=false)
+	if enabled, err := strconv.ParseBool(os.Getenv("TENANT_VALIDATION")); err != nil || enabled {
+		executor.SetTenantProvider(delivery.GetTenant)
+	} else {
+		utils.Log.Warn().Msg("⚠️ TENANT_VALIDATION=false, tenant tidak divalidasi ke TenantManager")
+	}
+
+	// Endpoint RAG per tenant (knowledge base terisolasi), tenant lain tetap ke endpoint default
+	if err := ragclient.LoadTenantRoutes(); err != nil {
+		utils.Log.Fatal().Err(err).Msg("❌ Failed to load RAG tenant routes")
+	}
+
+	if *selfTestOnly {
+		err := delivery.RunSelfTest(context.Background())
+		delivery.CloseKafkaWriter()
+		observer.CloseKafkaWriter()
+		tracing.Shutdown(context.Background())
+		if err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Watcher direktori flow (inotify, fallback polling FLOW_WATCH_INTERVAL): validasi ulang + invalidasi cache saat file berubah
+	watchCtx, stopWatcher := context.WithCancel(context.Background())
+	watcher := flowwatch.Start(watchCtx)
+
 	// HTTP server mux
 	mux := http.NewServeMux()
 
-	// Health check endpoint
+	// Health check endpoint (liveness murni, tanpa cek dependency)
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("OK"))
 	})
 
+	// Readiness: 503 jika Kafka / RAG / compiler belum siap
+	mux.HandleFunc("/readyz", handleReadyz)
+
 	// Endpoint untuk menjalankan sample flow
 	mux.HandleFunc("/run-sample", func(w http.ResponseWriter, r *http.Request) {
-		err := executor.RunFlowFromFile("flows/examples/sample_flow.json")
+		err := executor.RunFlowFromFile(filepath.Join(loader.ExamplesDir(), "sample_flow.json"))
 		if err != nil {
 			utils.Log.Error().Err(err).Msg("❌ Error running sample flow")
 			http.Error(w, "❌ Error running flow: "+err.Error(), http.StatusInternalServerError)
@@ -57,7 +130,7 @@ func main() {
 
 	// Endpoint untuk menjalankan order menu flow
 	mux.HandleFunc("/run-order-menu", func(w http.ResponseWriter, r *http.Request) {
-		err := executor.RunFlowFromFile("flows/examples/order_menu.json")
+		err := executor.RunFlowFromFile(filepath.Join(loader.ExamplesDir(), "order_menu.json"))
 		if err != nil {
 			utils.Log.Error().Err(err).Msg("❌ Error running order_menu flow")
 			http.Error(w, "❌ Error running flow: "+err.Error(), http.StatusInternalServerError)
@@ -69,61 +142,62 @@ func main() {
 	// Endpoint untuk menjalankan flow dari file .pb
 	mux.HandleFunc("/run-from-pb", handleRunFromPB)
 
-	// Endpoint baru untuk EKSEKUSI flow dari file dengan dukungan input POST
-	mux.HandleFunc("/run-flow/", func(w http.ResponseWriter, r *http.Request) {
-		filename := strings.TrimPrefix(r.URL.Path, "/run-flow/")
-		fullpath := filepath.Join("flows/examples", filename)
+	// Endpoint untuk daftar flow yang tersedia (dipakai management UI)
+	mux.HandleFunc("/flows", handleListFlows)
 
-		// Coba override jika file ada di flows/global/
-		globalPath := filepath.Join("flows/global", filename)
-		if _, err := os.Stat(globalPath); err == nil {
-			fullpath = globalPath
-		}
+	// Dump FlowSpec hasil parse + validasi tanpa eksekusi (hanya jika FLOW_DEBUG=true)
+	mux.HandleFunc("/debug/flow/", handleDebugFlow)
 
-		// Parse input dari POST body (jika ada)
-		var input map[string]interface{}
-		if r.Method == http.MethodPost {
-			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-				utils.Log.Warn().Err(err).Msg("⚠️ Tidak bisa parse input JSON")
-				input = map[string]interface{}{}
-			}
-		}
+	// Endpoint upload flow ke flows/global tanpa redeploy
+	mux.HandleFunc("/flows/", handleUploadFlow)
 
-		utils.Log.Debug().Interface("input", input).Msg("🟡 Received Input")
+	// Trigger manual hot-reload (pattern lebih spesifik dari /flows/, jadi tidak dianggap upload)
+	mux.HandleFunc("/flows/reload", handleReloadFlows(watcher))
 
-		// ✅ FIX: Gunakan RunFlowAndReturnOutput untuk mendapatkan hasil
-		result, err := executor.RunFlowAndReturnOutput(fullpath, input)
-		if err != nil {
-			utils.Log.Error().Err(err).Str("filename", filename).Msg("❌ Error running flow")
-			http.Error(w, "❌ Error running flow: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+	// Endpoint daftar hoop yang bisa dipakai di flow
+	mux.HandleFunc("/hoops", handleListHoops)
 
-		// ✅ FIX: Kirim hasil sebagai JSON response
-		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(map[string]interface{}{
-			"status": "success",
-			"result": result,
-		}); err != nil {
-			utils.Log.Error().Err(err).Msg("❌ Error encoding JSON response")
-			http.Error(w, "❌ Error encoding response", http.StatusInternalServerError)
-			return
-		}
+	// Endpoint dry-run: validasi flow tanpa mengeksekusi node
+	mux.HandleFunc("/validate-flow/", handleValidateFlow)
 
-		utils.Log.Info().
-			Str("filename", filename).
-			Str("fullpath", fullpath).
-			Interface("result", result).
-			Msg("✅ Flow executed successfully")
-	})
+	// Endpoint visualisasi flow sebagai Graphviz DOT
+	mux.HandleFunc("/flow-graph/", handleFlowGraph)
+
+	// Endpoint untuk melanjutkan flow yang terputus dari checkpoint terakhir
+	mux.HandleFunc("/resume-flow/", handleResumeFlow)
+
+	// Endpoint EKSEKUSI flow dari file dengan dukungan input POST (handler kanonik di delivery)
+	mux.HandleFunc("/run-flow/", delivery.HandleRunFlow)
+
+	// Streaming progress per node via WebSocket (frame input pertama dari client)
+	mux.Handle("/ws/run-flow/", delivery.HandleRunFlowWS)
+
+	// Varian body JSON {flow_path, input}, kontrak response sama dengan /run-flow/
+	mux.HandleFunc("/flow/execute", delivery.HandleFlowExecute)
 
 	// Endpoint untuk Prometheus metrics
 	mux.Handle("/metrics", promhttp.Handler())
 
-	// Konfigurasi HTTP server dengan graceful shutdown
+	// Rate limit (token bucket global + per IP) dicek sebelum auth, lalu
+	// API key auth (X-API-Key); /healthz, /readyz & /metrics dikecualikan dari keduanya
+	apiKeys, err := delivery.LoadAPIKeys()
+	if err != nil {
+		utils.Log.Fatal().Err(err).Msg("❌ Failed to load API keys")
+	}
+
+	// Konfigurasi HTTP server dengan graceful shutdown; port & timeout dari ENV.
+	// WriteTimeout dibuat longgar (default 120s) karena flow berbasis RAG bisa lama.
+	port := os.Getenv("HTTP_PORT")
+	if port == "" {
+		port = "8088"
+	}
 	server := &http.Server{
-		Addr:    ":8088",
-		Handler: mux,
+		Addr:              ":" + port,
+		Handler:           delivery.RateLimit(delivery.RateLimiterFromEnv(), delivery.APIKeyAuth(apiKeys, mux)),
+		ReadHeaderTimeout: envDuration("HTTP_READ_HEADER_TIMEOUT", 10*time.Second),
+		ReadTimeout:       envDuration("HTTP_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:      envDuration("HTTP_WRITE_TIMEOUT", 120*time.Second),
+		IdleTimeout:       envDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
 	}
 
 	// Channel untuk menangani shutdown
@@ -132,12 +206,30 @@ func main() {
 
 	// Jalankan server di goroutine
 	go func() {
-		utils.Log.Info().Msg("🌐 HTTP server running on :8088")
+		utils.Log.Info().
+			Str("addr", server.Addr).
+			Dur("write_timeout", server.WriteTimeout).
+			Msg("🌐 HTTP server running")
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			utils.Log.Fatal().Err(err).Msg("❌ Server error")
 		}
 	}()
 
+	// Smoke test SELFTEST_FLOW di background; /readyz 503 sampai self-test sukses
+	if delivery.SelfTestFlow() != "" {
+		go delivery.RunSelfTest(context.Background())
+	}
+
+	// gRPC server (FlowExecutorService) berjalan berdampingan dengan HTTP, pakai API key yang sama (metadata x-api-key)
+	grpcServer := delivery.StartGRPCServer(apiKeys)
+
+	// Scheduler flow berbasis cron (SCHEDULE_CONFIG), dihentikan lewat cancel saat shutdown
+	schedCtx, stopScheduler := context.WithCancel(context.Background())
+	sched, err := scheduler.Start(schedCtx)
+	if err != nil {
+		utils.Log.Fatal().Err(err).Msg("❌ Failed to start scheduler")
+	}
+
 	// Tunggu sinyal shutdown
 	<-stop
 	utils.Log.Info().Msg("🛑 Shutdown signal received, stopping server...")
@@ -146,19 +238,418 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
-		utils.Log.Fatal().Err(err).Msg("❌ Server forced to shutdown")
+		// Jangan Fatal di sini: writer Kafka di bawah tetap harus di-flush
+		utils.Log.Error().Err(err).Msg("❌ Server forced to shutdown")
 	}
+	grpcServer.GracefulStop()
+	stopScheduler()
+	sched.Wait()
+	stopWatcher()
+	watcher.Wait()
+
+	// Flush pesan Kafka yang masih di-buffer sebelum exit
+	delivery.CloseKafkaWriter()
+	observer.CloseKafkaWriter()
+	ragclient.ClosePool()
+	tracing.Shutdown(ctx)
 
 	utils.Log.Info().Msg("✅ Server gracefully stopped.")
 }
 
+// envDuration membaca durasi Go (mis. "30s") dari ENV, fallback ke def jika kosong/invalid
+func envDuration(name string, def time.Duration) time.Duration {
+	if v := os.Getenv(name); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+		utils.Log.Warn().Str("env", name).Str("value", v).Msg("⚠️ Durasi tidak valid, pakai default")
+	}
+	return def
+}
+
+func handleReadyz(w http.ResponseWriter, r *http.Request) {
+	deps, ready := delivery.CheckReadiness(r.Context())
+	code := http.StatusOK
+	if !ready {
+		code = http.StatusServiceUnavailable
+		utils.Log.Warn().Interface("dependencies", deps).Msg("⚠️ Readiness check failed")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ready":        ready,
+		"dependencies": deps,
+	})
+}
+
 func handleRunFromPB(w http.ResponseWriter, r *http.Request) {
-	err := executor.RunProtobufFlowFromFile("flows/compiled/sample_flow.pb")
+	err := executor.RunProtobufFlowFromFile(filepath.Join(loader.CompiledDir(), "sample_flow.pb"))
 	if err != nil {
 		utils.Log.Error().Err(err).Msg("❌ Failed to execute flow from .pb")
-		http.Error(w, "❌ Flow execution failed: "+err.Error(), http.StatusInternalServerError)
+		delivery.WriteFlowError(w, err, "")
 		return
 	}
 
 	fmt.Fprintln(w, "✅ Flow from .pb executed successfully.")
-}
\ No newline at end of file
+}
+
+// flowDirs adalah direktori yang di-scan untuk daftar flow
+func flowDirs() []string {
+	return []string{loader.ExamplesDir(), loader.GlobalDir()}
+}
+
+// flowSummary adalah satu entry di response GET /flows
+type flowSummary struct {
+	Name      string `json:"name"`
+	Dir       string `json:"dir"`
+	FlowID    string `json:"flow_id,omitempty"`
+	NodeCount int    `json:"node_count"`
+	Error     string `json:"error,omitempty"`
+}
+
+func handleListFlows(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flows := []flowSummary{}
+	for _, dir := range flowDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			// Direktori tidak ada bukan error fatal, cukup dilewati
+			utils.Log.Warn().Err(err).Str("dir", dir).Msg("⚠️ Tidak bisa membaca direktori flow")
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || (filepath.Ext(entry.Name()) != ".json" && !loader.IsYAMLFlow(entry.Name())) {
+				continue
+			}
+			flows = append(flows, summarizeFlow(dir, entry.Name()))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(flows); err != nil {
+		utils.Log.Error().Err(err).Msg("❌ Error encoding flow list")
+	}
+}
+
+// summarizeFlow membaca satu file flow; file rusak tetap dilaporkan dengan field error
+func summarizeFlow(dir, name string) flowSummary {
+	summary := flowSummary{Name: name, Dir: dir}
+
+	data, err := loader.ReadFlowJSON(filepath.Join(dir, name))
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+
+	var flow executor.FlowSpec
+	if err := json.Unmarshal(data, &flow); err != nil {
+		summary.Error = "invalid JSON: " + err.Error()
+		return summary
+	}
+
+	summary.FlowID = flow.FlowID
+	summary.NodeCount = len(flow.Nodes)
+	return summary
+}
+
+// handleReloadFlows memvalidasi ulang semua flow dan meng-invalidasi cache: POST /flows/reload
+func handleReloadFlows(watcher *flowwatch.Watcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		statuses := watcher.Reload()
+
+		invalid := 0
+		for _, st := range statuses {
+			if !st.Valid {
+				invalid++
+			}
+		}
+		utils.Log.Info().Int("flows", len(statuses)).Int("invalid", invalid).Msg("🔄 Flows reloaded manually")
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"reloaded": len(statuses),
+			"invalid":  invalid,
+			"flows":    statuses,
+		})
+	}
+}
+
+// handleListHoops mengembalikan nama hoop terdaftar (plus IfNode yang ditangani engine)
+func handleListHoops(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	hoops := append(executor.RegisteredHoops(), "IfNode")
+	sort.Strings(hoops)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"hoops": hoops})
+}
+
+// handleValidateFlow menjalankan validasi struktur + resolusi urutan eksekusi.
+// Flow tidak valid tetap 200 dengan valid:false supaya editor bisa menampilkan detailnya.
+func handleValidateFlow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filename := strings.TrimPrefix(r.URL.Path, "/validate-flow/")
+	fullpath, err := loader.ResolveFlowPath(filename)
+	if err != nil {
+		utils.Log.Warn().Err(err).Str("filename", filename).Msg("🚫 Suspicious flow name")
+		http.Error(w, "❌ "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := loader.ReadFlowJSON(fullpath)
+	if errors.Is(err, os.ErrNotExist) {
+		utils.Log.Warn().Err(err).Str("filename", filename).Msg("⚠️ Flow tidak ditemukan untuk validasi")
+		http.Error(w, "❌ Flow not found: "+filename, http.StatusNotFound)
+		return
+	}
+
+	var result executor.FlowValidation
+	var flow executor.FlowSpec
+	if err != nil {
+		// YAML rusak dilaporkan sebagai problem, bukan 404
+		result = executor.FlowValidation{
+			Problems:       []string{err.Error()},
+			ExecutionOrder: []string{},
+		}
+	} else if err := json.Unmarshal(data, &flow); err != nil {
+		result = executor.FlowValidation{
+			Problems:       []string{"invalid JSON: " + err.Error()},
+			ExecutionOrder: []string{},
+		}
+	} else {
+		result = executor.ValidateFlow(flow)
+	}
+
+	utils.Log.Info().
+		Str("filename", filename).
+		Bool("valid", result.Valid).
+		Int("problems", len(result.Problems)).
+		Msg("🔎 Flow validated (dry-run)")
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+		"flow":            filename,
+		"flow_id":         flow.FlowID,
+		"valid":           result.Valid,
+		"problems":        result.Problems,
+		"warnings":        result.Warnings,
+		"execution_order": result.ExecutionOrder,
+	}); err != nil {
+		utils.Log.Error().Err(err).Msg("❌ Error encoding validation response")
+	}
+}
+
+// handleFlowGraph mengembalikan DAG flow dalam format DOT: GET /flow-graph/{name}
+func handleFlowGraph(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filename := strings.TrimPrefix(r.URL.Path, "/flow-graph/")
+	fullpath, err := loader.ResolveFlowPath(filename)
+	if err != nil {
+		utils.Log.Warn().Err(err).Str("filename", filename).Msg("🚫 Suspicious flow name")
+		http.Error(w, "❌ "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := loader.ReadFlowJSON(fullpath)
+	if errors.Is(err, os.ErrNotExist) {
+		http.Error(w, "❌ Flow not found: "+filename, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "❌ "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var flow executor.FlowSpec
+	if err := json.Unmarshal(data, &flow); err != nil {
+		http.Error(w, "❌ Malformed flow JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vnd.graphviz")
+	io.WriteString(w, executor.FlowToDOT(flow))
+}
+
+// handleDebugFlow mengembalikan representasi internal flow: GET /debug/flow/{name}.
+// Beda dengan /validate-flow: FlowSpec ditampilkan utuh beserta path & entry node hasil resolve.
+func handleDebugFlow(w http.ResponseWriter, r *http.Request) {
+	if !utils.DebugEnabled() {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filename := strings.TrimPrefix(r.URL.Path, "/debug/flow/")
+	fullpath, err := loader.ResolveFlowPath(filename)
+	if err != nil {
+		http.Error(w, "❌ "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := loader.ReadFlowJSON(fullpath)
+	if errors.Is(err, os.ErrNotExist) {
+		http.Error(w, "❌ Flow not found: "+filename, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "❌ "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var flow executor.FlowSpec
+	if err := json.Unmarshal(data, &flow); err != nil {
+		http.Error(w, "❌ Malformed flow JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entryNode, entryErr := executor.EntryNode(flow)
+	resp := map[string]interface{}{
+		"flow":       filename,
+		"path":       fullpath,
+		"base_dir":   filepath.Dir(fullpath),
+		"spec":       flow,
+		"entry_node": entryNode,
+		"validation": executor.ValidateFlow(flow),
+	}
+	if entryErr != nil {
+		resp["entry_error"] = entryErr.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(resp)
+}
+
+// Batas ukuran file flow yang di-upload
+const maxUploadBytes = 1 << 20
+
+// handleUploadFlow menerima body JSON flow, memvalidasi struktur + cycle, lalu menyimpannya.
+func handleUploadFlow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Flow di GlobalDir dipakai semua tenant (dan bisa menulis tenant_id apa saja di parameter node),
+	// jadi hanya API key tanpa scope tenant (admin) yang boleh upload
+	if tenant := delivery.ScopedTenant(r.Context()); tenant != "" {
+		utils.Log.Warn().Str("tenant_id", tenant).Msg("🚫 Upload flow ditolak untuk API key ber-scope tenant")
+		delivery.WriteError(w, http.StatusForbidden, delivery.CodeForbidden, "API key ber-scope tenant tidak boleh upload flow")
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/flows/")
+	// Tolak separator dan ".." supaya tidak bisa menulis di luar direktori flow
+	if err := loader.ValidateFlowName(name); err != nil {
+		http.Error(w, "❌ "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filepath.Ext(name) != ".json" {
+		name += ".json"
+	}
+
+	// Body disimpan apa adanya, jadi dibaca raw (tetap dengan batas ukuran)
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadBytes))
+	if err != nil {
+		http.Error(w, "❌ Request body too large or unreadable: "+err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var flow executor.FlowSpec
+	if err := json.Unmarshal(data, &flow); err != nil {
+		http.Error(w, "❌ Malformed flow JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := executor.ValidateFlow(flow)
+	if !result.Valid {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"flow":     name,
+			"valid":    false,
+			"problems": result.Problems,
+			"warnings": result.Warnings,
+		})
+		return
+	}
+
+	// Upload selalu ke GlobalDir supaya meng-override flows/examples
+	flowUploadDir := loader.GlobalDir()
+	if err := os.MkdirAll(flowUploadDir, 0755); err != nil {
+		utils.Log.Error().Err(err).Msg("❌ Failed to create flow directory")
+		http.Error(w, "❌ Failed to store flow", http.StatusInternalServerError)
+		return
+	}
+	path := filepath.Join(flowUploadDir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		utils.Log.Error().Err(err).Str("path", path).Msg("❌ Failed to write flow file")
+		http.Error(w, "❌ Failed to store flow", http.StatusInternalServerError)
+		return
+	}
+
+	utils.Log.Info().Str("path", path).Str("flow_id", flow.FlowID).Msg("📥 Flow uploaded")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"path":     path,
+		"flow_id":  flow.FlowID,
+		"warnings": result.Warnings,
+	})
+}
+
+// handleResumeFlow melanjutkan eksekusi dari checkpoint: POST /resume-flow/{execution_id}.
+// API key ber-scope tenant hanya bisa me-resume execution milik tenant-nya sendiri.
+func handleResumeFlow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		delivery.WriteError(w, http.StatusMethodNotAllowed, delivery.CodeValidationFailed, "method not allowed")
+		return
+	}
+
+	executionID := strings.TrimPrefix(r.URL.Path, "/resume-flow/")
+	if executionID == "" {
+		delivery.WriteError(w, http.StatusBadRequest, delivery.CodeValidationFailed, "execution_id wajib diisi")
+		return
+	}
+
+	w.Header().Set("X-Execution-ID", executionID)
+	result, err := executor.ResumeFlow(r.Context(), executionID, delivery.ScopedTenant(r.Context()))
+	if err != nil {
+		utils.Log.Error().Err(err).Str("execution_id", executionID).Msg("❌ Error resuming flow")
+		delivery.WriteFlowError(w, err, executionID)
+		return
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"status":       "success",
+		"execution_id": executionID,
+		"result":       result,
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(utils.Redact(string(body)) + "\n"))
+}
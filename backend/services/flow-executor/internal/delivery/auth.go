@@ -0,0 +1,169 @@
+package delivery
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// APIKey: Tenant kosong berarti key boleh menjalankan flow untuk tenant manapun
+type APIKey struct {
+	Key    string
+	Tenant string
+}
+
+type tenantCtxKey struct{}
+
+type apiKeyCtxKey struct{}
+
+// authExemptPaths tidak butuh API key (probe & scrape dari infra internal)
+var authExemptPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// LoadAPIKeys membaca key dari ENV API_KEYS dan/atau file API_KEYS_FILE.
+// Format per entry: "key" atau "key:tenant_id"; di ENV dipisah koma, di file satu per baris (# = komentar).
+func LoadAPIKeys() ([]APIKey, error) {
+	var entries []string
+	if v := os.Getenv("API_KEYS"); v != "" {
+		entries = append(entries, strings.Split(v, ",")...)
+	}
+	if path := os.Getenv("API_KEYS_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open API_KEYS_FILE: %w", err)
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			entries = append(entries, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read API_KEYS_FILE: %w", err)
+		}
+	}
+
+	var keys []APIKey
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" || strings.HasPrefix(e, "#") {
+			continue
+		}
+		key, tenant, _ := strings.Cut(e, ":")
+		keys = append(keys, APIKey{Key: strings.TrimSpace(key), Tenant: strings.TrimSpace(tenant)})
+	}
+	return keys, nil
+}
+
+// APIKeyAuth membungkus handler dengan cek header X-API-Key (untuk /ws/ lihat requestAPIKey).
+// Jika tidak ada key yang dikonfigurasi, auth dimatikan (mode dev) dengan warning saat startup.
+func APIKeyAuth(keys []APIKey, next http.Handler) http.Handler {
+	logger := utils.Component("auth")
+	if len(keys) == 0 {
+		logger.Warn().Msg("⚠️ API_KEYS / API_KEYS_FILE tidak diset, endpoint HTTP tanpa autentikasi")
+		return next
+	}
+	logger.Info().Int("keys", len(keys)).Msg("🔐 API key auth aktif")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authExemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if k, ok := matchAPIKey(keys, requestAPIKey(r)); ok {
+			next.ServeHTTP(w, r.WithContext(withAPIKey(r.Context(), k)))
+			return
+		}
+
+		logger.Warn().Str("path", r.URL.Path).Str("remote", r.RemoteAddr).Msg("🚫 Invalid or missing API key")
+		http.Error(w, "❌ Unauthorized: invalid or missing X-API-Key", http.StatusUnauthorized)
+	})
+}
+
+// wsAPIKeyProtocol adalah prefix subprotocol WebSocket pembawa API key ("api-key.<key>"),
+// karena browser tidak bisa mengirim header custom saat membuka WebSocket
+const wsAPIKeyProtocol = "api-key."
+
+// requestAPIKey mengambil key dari header X-API-Key. Khusus /ws/, key juga boleh lewat
+// subprotocol "api-key.<key>" atau query ?api_key= (fallback untuk client tanpa subprotocol).
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" || !strings.HasPrefix(r.URL.Path, "/ws/") {
+		return key
+	}
+	for _, p := range strings.Split(r.Header.Get("Sec-WebSocket-Protocol"), ",") {
+		if p = strings.TrimSpace(p); strings.HasPrefix(p, wsAPIKeyProtocol) {
+			return strings.TrimPrefix(p, wsAPIKeyProtocol)
+		}
+	}
+	return r.URL.Query().Get("api_key")
+}
+
+// matchAPIKey mencari key yang cocok (constant-time compare); dipakai auth HTTP & gRPC
+func matchAPIKey(keys []APIKey, provided string) (APIKey, bool) {
+	for _, k := range keys {
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(k.Key)) == 1 {
+			return k, true
+		}
+	}
+	return APIKey{}, false
+}
+
+// withAPIKey memasang tenant scope + identitas key ke ctx (dibaca ScopedTenant / APIKeyID)
+func withAPIKey(ctx context.Context, k APIKey) context.Context {
+	ctx = context.WithValue(ctx, tenantCtxKey{}, k.Tenant)
+	return context.WithValue(ctx, apiKeyCtxKey{}, keyID(k.Key))
+}
+
+// ScopedTenant mengembalikan tenant dari API key pada request (kosong = tidak di-scope)
+func ScopedTenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantCtxKey{}).(string)
+	return tenant
+}
+
+// APIKeyID mengembalikan identitas (hash) API key pada request, kosong jika auth nonaktif.
+// Key asli tidak pernah disimpan di context / store.
+func APIKeyID(ctx context.Context) string {
+	id, _ := ctx.Value(apiKeyCtxKey{}).(string)
+	return id
+}
+
+func keyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// EnforceTenant memastikan input hanya menyasar tenant milik API key.
+// tenant_id di input (root atau nested "input") harus sama; jika kosong, tenant key di-inject.
+func EnforceTenant(ctx context.Context, input map[string]interface{}) error {
+	scoped := ScopedTenant(ctx)
+	if scoped == "" || input == nil {
+		return nil
+	}
+
+	if tenant, ok := input["tenant_id"].(string); ok && tenant != "" && tenant != scoped {
+		return fmt.Errorf("API key is not allowed to run flows for tenant %q", tenant)
+	}
+
+	nested, _ := input["input"].(map[string]interface{})
+	if nested == nil {
+		nested = map[string]interface{}{}
+		input["input"] = nested
+	}
+	if tenant, ok := nested["tenant_id"].(string); ok && tenant != "" && tenant != scoped {
+		return fmt.Errorf("API key is not allowed to run flows for tenant %q", tenant)
+	}
+	// Executor mengambil tenant dari input.tenant_id, jadi ini meng-override tenant di file flow
+	nested["tenant_id"] = scoped
+	return nil
+}
@@ -0,0 +1,79 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// Default batas body request run endpoint (1 MiB), override via ENV MAX_REQUEST_BODY_BYTES
+const defaultMaxBodyBytes int64 = 1 << 20
+
+func maxBodyBytes() int64 {
+	if v := os.Getenv("MAX_REQUEST_BODY_BYTES"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultMaxBodyBytes
+}
+
+// BodyError membawa status HTTP yang tepat: 413 untuk body terlalu besar, 400 untuk JSON rusak
+type BodyError struct {
+	Status int
+	Msg    string
+}
+
+func (e *BodyError) Error() string { return e.Msg }
+
+// DecodeJSONBody decode body JSON dengan batas ukuran dan DisallowUnknownFields.
+// DisallowUnknownFields hanya berlaku untuk dst berupa struct; input flow (map) divalidasi
+// executor terhadap input_schema flow. Body kosong tidak dianggap error (dst dibiarkan apa adanya).
+func DecodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	limit := maxBodyBytes()
+	r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return classifyBodyError(err, limit)
+	}
+
+	// Tolak data tambahan setelah objek JSON pertama
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return classifyBodyError(err, limit)
+		}
+		return &BodyError{Status: http.StatusBadRequest, Msg: "malformed JSON: body must contain a single JSON object"}
+	}
+	return nil
+}
+
+func classifyBodyError(err error, limit int64) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return &BodyError{
+			Status: http.StatusRequestEntityTooLarge,
+			Msg:    fmt.Sprintf("request body too large: limit is %d bytes", limit),
+		}
+	}
+	return &BodyError{Status: http.StatusBadRequest, Msg: "malformed JSON: " + err.Error()}
+}
+
+// WriteBodyError menulis BodyError ke response (JSON ErrorResponse) dengan status yang sesuai
+func WriteBodyError(w http.ResponseWriter, err error) {
+	var bodyErr *BodyError
+	if errors.As(err, &bodyErr) {
+		WriteError(w, bodyErr.Status, CodeValidationFailed, bodyErr.Msg)
+		return
+	}
+	WriteError(w, http.StatusBadRequest, CodeValidationFailed, err.Error())
+}
@@ -36,7 +36,11 @@ func DummySendNotification(ctx context.Context, input map[string]interface{}) (m
 		return nil, fmt.Errorf("marshal failed: %w", err)
 	}
 
-	if err := PublishNotification(payload); err != nil {
+	userID, _ := input["user_id"].(string)
+	traceID, _ := input["trace_id"].(string)
+	tenantID, _ := input["tenant_id"].(string)
+	headers := map[string]string{"trace_id": traceID, "tenant_id": tenantID}
+	if err := PublishNotification([]byte(userID), payload, headers); err != nil {
 		return nil, fmt.Errorf("kafka publish failed: %w", err)
 	}
 
@@ -2,47 +2,132 @@ package delivery
 
 import (
 	"context"
-	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/segmentio/kafka-go"
+
+	"github.com/milkyhoop/flow-executor/internal/kafkautil"
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
 var kafkaWriter *kafka.Writer
 
+// KafkaWriterReady true jika writer Kafka sudah diinisialisasi (dipakai /readyz)
+func KafkaWriterReady() bool {
+	return kafkaWriter != nil
+}
+
 // InitKafkaWriter inisialisasi writer Kafka (dipanggil saat startup)
-func InitKafkaWriter() {
-	brokers := os.Getenv("KAFKA_BROKER") // contoh: "localhost:9092"
-	if brokers == "" {
-		log.Println("⚠️ KAFKA_BROKER tidak diset, Kafka writer tidak aktif")
-		return
+func InitKafkaWriter() error {
+	brokers := kafkautil.Brokers() // contoh: "localhost:9092" atau "k1:9092,k2:9092"
+	if len(brokers) == 0 {
+		utils.Component("delivery").Warn().Msg("⚠️ KAFKA_BROKER(S) tidak diset, Kafka writer tidak aktif")
+		return nil
+	}
+	// SASL/TLS dari env, plaintext jika tidak diset; config invalid → error, bukan fallback
+	dialer, err := kafkautil.Dialer()
+	if err != nil {
+		return err
 	}
 
+	// Batching: flush saat jumlah pesan mencapai BatchSize atau BatchTimeout lewat
+	batchSize := 100
+	if v := os.Getenv("KAFKA_BATCH_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			batchSize = n
+		}
+	}
+	batchTimeout := time.Second
+	if v := os.Getenv("KAFKA_BATCH_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			batchTimeout = d
+		}
+	}
+	// Async: WriteMessages tidak menunggu flush, pesan di-buffer oleh writer
+	async, _ := strconv.ParseBool(os.Getenv("KAFKA_ASYNC"))
+
 	kafkaWriter = kafka.NewWriter(kafka.WriterConfig{
-		Brokers:  []string{brokers},
-		Topic:    "send-notification",
-		Balancer: &kafka.LeastBytes{},
+		Brokers:      brokers,
+		Dialer:       dialer,
+		Topic:        "send-notification",
+		Balancer:     &kafka.Hash{},
+		BatchSize:    batchSize,
+		BatchTimeout: batchTimeout,
+		Async:        async,
 	})
+	if async {
+		kafkaWriter.Completion = func(messages []kafka.Message, err error) {
+			if err != nil {
+				observer.NotificationPublishFailures.WithLabelValues("send-notification").Add(float64(len(messages)))
+				utils.Component("delivery").Error().Err(err).Int("messages", len(messages)).Msg("❌ Gagal kirim pesan ke Kafka (async)")
+			}
+		}
+	}
+
+	utils.Component("delivery").Info().
+		Str("topic", "send-notification").
+		Strs("brokers", brokers).
+		Int("batch_size", batchSize).
+		Dur("batch_timeout", batchTimeout).
+		Bool("async", async).
+		Msg("📡 Kafka writer siap")
+	return nil
+}
 
-	log.Printf("📡 Kafka writer siap → topic: send-notification, broker: %s\n", brokers)
+// CloseKafkaWriter flush pesan yang masih di-buffer lalu menutup writer (dipanggil saat shutdown)
+func CloseKafkaWriter() {
+	if kafkaWriter == nil {
+		return
+	}
+	if err := kafkaWriter.Close(); err != nil {
+		utils.Component("delivery").Error().Err(err).Msg("❌ Gagal menutup Kafka writer")
+		return
+	}
+	utils.Component("delivery").Info().Msg("✅ Kafka writer flushed & closed")
 }
 
-// PublishNotification mengirim payload notifikasi ke Kafka
-func PublishNotification(payload []byte) error {
+// PublishNotification mengirim payload notifikasi ke Kafka.
+// key (mis. user_id / tenant_id) menentukan partisi supaya urutan per user terjaga,
+// headers (trace_id, tenant_id) dipakai notification-service untuk korelasi log.
+func PublishNotification(key []byte, payload []byte, headers map[string]string) error {
+	return PublishNotificationBatch(key, [][]byte{payload}, headers)
+}
+
+// PublishNotificationBatch mengirim beberapa payload dengan key & header yang sama dalam satu WriteMessages
+func PublishNotificationBatch(key []byte, payloads [][]byte, headers map[string]string) error {
 	if kafkaWriter == nil {
 		return nil // Kafka tidak aktif, skip (bisa di-log)
 	}
+	if len(payloads) == 0 {
+		return nil
+	}
+
+	var kafkaHeaders []kafka.Header
+	for k, v := range headers {
+		if v == "" {
+			continue
+		}
+		kafkaHeaders = append(kafkaHeaders, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	messages := make([]kafka.Message, 0, len(payloads))
+	for _, payload := range payloads {
+		messages = append(messages, kafka.Message{Key: key, Value: payload, Headers: kafkaHeaders})
+	}
 
-	err := kafkaWriter.WriteMessages(context.Background(),
-		kafka.Message{
-			Value: payload,
-		},
-	)
+	// Timeout supaya Kafka down tidak memblokir flow; pesan gagal di-drop (log + metric)
+	ctx, cancel := context.WithTimeout(context.Background(), kafkautil.PublishTimeout())
+	defer cancel()
+	err := kafkaWriter.WriteMessages(ctx, messages...)
 	if err != nil {
-		log.Printf("❌ Gagal kirim ke Kafka: %v", err)
+		observer.NotificationPublishFailures.WithLabelValues("send-notification").Inc()
+		utils.Component("delivery").Error().Err(err).Int("payloads", len(payloads)).Msg("❌ Gagal kirim ke Kafka")
 		return err
 	}
 
-	log.Printf("📤 Payload dikirim ke Kafka: %s", string(payload))
+	utils.Component("delivery").Debug().Int("payloads", len(payloads)).Msg("📤 Payload dikirim ke Kafka")
 	return nil
 }
@@ -0,0 +1,124 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+	"github.com/milkyhoop/flow-executor/internal/statestore"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Kode error API yang stabil, dipakai client untuk handling tanpa string-matching
+const (
+	CodeFlowNotFound          = "flow_not_found"
+	CodeValidationFailed      = "validation_failed"
+	CodeNodeFailed            = "node_failed"
+	CodeDependencyUnavailable = "dependency_unavailable"
+	CodeForbidden             = "forbidden"
+	CodeRateLimited           = "rate_limited"
+	CodeIdempotencyConflict   = "idempotency_conflict"
+	CodeCheckpointNotFound    = "checkpoint_not_found"
+	CodeResumeInProgress      = "resume_in_progress"
+)
+
+// ErrorResponse adalah body JSON untuk semua error endpoint eksekusi flow
+type ErrorResponse struct {
+	Status      string `json:"status"`
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	ExecutionID string `json:"execution_id,omitempty"`
+
+	// PartialOutputs: output node yang sudah sukses sebelum gagal (hanya jika diminta, ?partial=true)
+	PartialOutputs map[string]map[string]interface{} `json:"partial_outputs,omitempty"`
+}
+
+// ClassifyFlowError memetakan error eksekusi flow ke kode error API + HTTP status
+func ClassifyFlowError(err error) (string, int) {
+	switch {
+	case errors.Is(err, executor.ErrFlowNotFound):
+		return CodeFlowNotFound, http.StatusNotFound
+	case errors.Is(err, statestore.ErrNotFound):
+		return CodeCheckpointNotFound, http.StatusNotFound
+	case errors.Is(err, executor.ErrResumeInProgress):
+		return CodeResumeInProgress, http.StatusConflict
+	case errors.Is(err, executor.ErrInvalidInput), errors.Is(err, executor.ErrUnknownHoop), errors.Is(err, executor.ErrInvalidEntry):
+		return CodeValidationFailed, http.StatusBadRequest
+	case errors.Is(err, executor.ErrTenantForbidden):
+		return CodeForbidden, http.StatusForbidden
+	case errors.Is(err, executor.ErrTenantConcurrencyLimit):
+		return CodeRateLimited, http.StatusTooManyRequests
+	case isDependencyError(err):
+		return CodeDependencyUnavailable, http.StatusServiceUnavailable
+	}
+	return CodeNodeFailed, http.StatusInternalServerError
+}
+
+// isDependencyError: upstream gRPC down/timeout atau circuit breaker RAG sedang open
+func isDependencyError(err error) bool {
+	if errors.Is(err, ragclient.ErrRAGUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// FlowErrorStatus memetakan error eksekusi flow ke HTTP status code
+func FlowErrorStatus(err error) int {
+	_, code := ClassifyFlowError(err)
+	return code
+}
+
+// WriteError menulis ErrorResponse JSON dengan status HTTP yang diberikan
+func WriteError(w http.ResponseWriter, httpStatus int, code, message string) {
+	writeErrorResponse(w, httpStatus, ErrorResponse{Status: "error", Code: code, Message: message})
+}
+
+// WriteFlowError menulis error eksekusi flow sesuai ClassifyFlowError
+func WriteFlowError(w http.ResponseWriter, err error, executionID string) {
+	code, httpStatus := ClassifyFlowError(err)
+	writeErrorResponse(w, httpStatus, ErrorResponse{
+		Status:      "error",
+		Code:        code,
+		Message:     err.Error(),
+		ExecutionID: executionID,
+	})
+}
+
+// WriteFlowErrorWithPartial sama dengan WriteFlowError, ditambah output node yang sudah sukses
+func WriteFlowErrorWithPartial(w http.ResponseWriter, err error, executionID string) {
+	code, httpStatus := ClassifyFlowError(err)
+	writeErrorResponse(w, httpStatus, ErrorResponse{
+		Status:         "error",
+		Code:           code,
+		Message:        err.Error(),
+		ExecutionID:    executionID,
+		PartialOutputs: executor.PartialOutputs(err),
+	})
+}
+
+func writeErrorResponse(w http.ResponseWriter, httpStatus int, resp ErrorResponse) {
+	body, _ := redactedJSON(resp)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	w.Write(body)
+}
+
+// redactedJSON meng-encode v (dengan newline seperti json.Encoder) lalu mengganti secret
+// terdaftar via utils.Redact; semua response, result store, dan hasil gRPC lewat sini
+func redactedJSON(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(utils.Redact(string(b)) + "\n"), nil
+}
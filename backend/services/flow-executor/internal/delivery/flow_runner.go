@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/milkyhoop/flow-executor/internal/loader"
 	"github.com/milkyhoop/flow-executor/internal/utils"
 	flowpb "github.com/milkyhoop/flow-executor/internal/proto/flow"
 
@@ -36,6 +37,9 @@ func LoadFlowFromProtobufFile(path string) (flowpb.Flow, error) {
 	if err := proto.Unmarshal(data, &protoFlow); err != nil {
 		return flowpb.Flow{}, fmt.Errorf("failed to unmarshal .pb: %w", err)
 	}
+	if err := loader.MigrateFlow(&protoFlow); err != nil {
+		return flowpb.Flow{}, err
+	}
 
 	return protoFlow, nil
 }
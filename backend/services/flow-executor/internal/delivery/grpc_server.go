@@ -0,0 +1,148 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/milkyhoop/grpcmw"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/loader"
+	pb "github.com/milkyhoop/flow-executor/internal/proto/flow_executor"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// FlowExecutorServer expose RunFlowAndReturnOutput via gRPC untuk caller internal
+type FlowExecutorServer struct {
+	pb.UnimplementedFlowExecutorServiceServer
+}
+
+func (s *FlowExecutorServer) ExecuteFlow(ctx context.Context, req *pb.ExecuteFlowRequest) (*pb.ExecuteFlowResponse, error) {
+	fullpath, err := loader.ResolveFlowPath(req.GetFlowName())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if _, err := os.Stat(fullpath); err != nil {
+		return nil, status.Errorf(codes.NotFound, "flow not found: %s", req.GetFlowName())
+	}
+
+	input := map[string]interface{}{}
+	if req.GetInputJson() != "" {
+		if err := json.Unmarshal([]byte(req.GetInputJson()), &input); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid input_json: %v", err)
+		}
+	}
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+
+	// Scope tenant API key sama seperti HTTP (key di-resolve oleh APIKeyUnaryInterceptor)
+	if err := EnforceTenant(ctx, input); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	executionID := executor.NewExecutionID()
+	ctx = executor.WithExecutionID(ctx, executionID)
+	result, err := executor.RunFlowAndReturnOutputContext(ctx, fullpath, input)
+	if errors.Is(err, executor.ErrFlowNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, executor.ErrInvalidInput) || errors.Is(err, executor.ErrUnknownHoop) || errors.Is(err, executor.ErrInvalidEntry) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, executor.ErrTenantForbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, executor.ErrTenantConcurrencyLimit) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		// Kegagalan flow dikembalikan di response, bukan gRPC error, supaya execution_id tetap sampai ke caller
+		utils.Log.Error().Err(err).Str("execution_id", executionID).Str("flow", req.GetFlowName()).Msg("❌ Error running flow (gRPC)")
+		return &pb.ExecuteFlowResponse{Status: "error", ExecutionId: executionID, Error: utils.Redact(err.Error())}, nil
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode result: %v", err)
+	}
+
+	return &pb.ExecuteFlowResponse{Status: "success", ResultJson: utils.Redact(string(resultJSON)), ExecutionId: executionID}, nil
+}
+
+// APIKeyUnaryInterceptor mewajibkan metadata x-api-key dari key set yang sama dengan HTTP
+// (API_KEYS / API_KEYS_FILE). Health check dikecualikan untuk probe infra.
+// Tanpa key yang dikonfigurasi, auth dimatikan (mode dev) seperti APIKeyAuth.
+func APIKeyUnaryInterceptor(keys []APIKey) grpc.UnaryServerInterceptor {
+	logger := utils.Component("auth")
+	if len(keys) == 0 {
+		logger.Warn().Msg("⚠️ API_KEYS / API_KEYS_FILE tidak diset, endpoint gRPC tanpa autentikasi")
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if len(keys) == 0 || strings.HasPrefix(info.FullMethod, "/grpc.health.v1.Health/") {
+			return handler(ctx, req)
+		}
+
+		var provided string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get("x-api-key"); len(v) > 0 {
+				provided = v[0]
+			}
+		}
+		k, ok := matchAPIKey(keys, provided)
+		if !ok {
+			logger.Warn().Str("method", info.FullMethod).Msg("🚫 Invalid or missing API key (gRPC)")
+			return nil, status.Error(codes.Unauthenticated, "invalid or missing x-api-key")
+		}
+		return handler(withAPIKey(ctx, k), req)
+	}
+}
+
+// StartGRPCServer menjalankan FlowExecutorService + health check di port GRPC_PORT (default 5010).
+// Server dikembalikan supaya main bisa GracefulStop saat shutdown.
+func StartGRPCServer(keys []APIKey) *grpc.Server {
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = "5010"
+	}
+
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		utils.Log.Fatal().Err(err).Str("port", port).Msg("❌ Failed to listen for gRPC")
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()),
+		grpcmw.UnaryServerInterceptors(*utils.Component("grpc")),
+		grpc.ChainUnaryInterceptor(APIKeyUnaryInterceptor(keys)),
+	)
+	pb.RegisterFlowExecutorServiceServer(grpcServer, &FlowExecutorServer{})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("flow_executor.FlowExecutorService", grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
+	// Reflection untuk debugging via grpcurl (opt-in lewat GRPC_REFLECTION)
+	grpcmw.RegisterReflection(grpcServer, *utils.Component("grpc"))
+
+	go func() {
+		utils.Log.Info().Msgf("🚀 gRPC FlowExecutorService running on :%s", port)
+		if err := grpcServer.Serve(lis); err != nil {
+			utils.Log.Fatal().Err(err).Msg("❌ gRPC server error")
+		}
+	}()
+
+	return grpcServer
+}
@@ -1,16 +1,77 @@
 package delivery
 
 import (
-	"encoding/json"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/loader"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
 	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
-// HandleFlowExecute menangani POST /flow/execute
+// FlowResponse adalah satu-satunya kontrak response sukses eksekusi flow.
+// Jawaban flow selalu ada di Result (output node terakhir).
+// Errors hanya terisi di mode continue_on_error (status "partial").
+type FlowResponse struct {
+	Status      string                 `json:"status"`
+	ExecutionID string                 `json:"execution_id"`
+	Flow        string                 `json:"flow"`
+	Result      map[string]interface{} `json:"result"`
+	Errors      []executor.NodeError   `json:"errors,omitempty"`
+}
+
+// HandleRunFlow menangani /run-flow/{name}; body POST (opsional) adalah input flow.
+// ?continue_on_error=true (debug, opt-in) mencatat error node di response alih-alih abort.
+func HandleRunFlow(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/run-flow/")
+
+	if continueOnError, _ := strconv.ParseBool(r.URL.Query().Get("continue_on_error")); continueOnError {
+		r = r.WithContext(executor.WithTrace(r.Context(), &executor.Trace{ContinueOnError: true}))
+	}
+
+	var input map[string]interface{}
+	if r.Method == http.MethodPost {
+		if err := DecodeJSONBody(w, r, &input); err != nil {
+			utils.Log.Warn().Err(err).Msg("⚠️ Tidak bisa parse input JSON")
+			WriteBodyError(w, err)
+			return
+		}
+	}
+
+	// Idempotency-Key: hasil sukses disimpan per (key, flow) dan di-replay saat client retry
+	if key := r.Header.Get(idempotencyHeader); key != "" {
+		// Cek scope tenant dulu: replay tidak boleh melewati EnforceTenant
+		if input == nil {
+			input = map[string]interface{}{}
+		}
+		if err := EnforceTenant(r.Context(), input); err != nil {
+			WriteError(w, http.StatusForbidden, CodeForbidden, err.Error())
+			return
+		}
+
+		storeKey := idempotencyStoreKey(r.Context(), key, name)
+		reqHash := requestHash(input)
+		if _, busy := inFlight.LoadOrStore(storeKey, struct{}{}); busy {
+			WriteError(w, http.StatusConflict, CodeIdempotencyConflict, "request dengan Idempotency-Key yang sama sedang diproses")
+			return
+		}
+		defer inFlight.Delete(storeKey)
+		if replayIdempotent(w, r, storeKey, reqHash) {
+			return
+		}
+
+		if resp := executeFlow(w, r, name, input); resp != nil {
+			saveIdempotent(r.Context(), storeKey, reqHash, resp)
+		}
+		return
+	}
+
+	executeFlow(w, r, name, input)
+}
+
+// HandleFlowExecute menangani POST /flow/execute dengan body {flow_path, input}
 func HandleFlowExecute(w http.ResponseWriter, r *http.Request) {
 	type Req struct {
 		FlowPath string                 `json:"flow_path"`
@@ -18,40 +79,87 @@ func HandleFlowExecute(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req Req
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "❌ Gagal parse JSON: "+err.Error(), http.StatusBadRequest)
+	if err := DecodeJSONBody(w, r, &req); err != nil {
+		WriteBodyError(w, err)
 		return
 	}
-
-	fullpath := filepath.Join("flows/global", req.FlowPath)
-	if _, err := os.Stat(fullpath); err != nil {
-		http.Error(w, "❌ File tidak ditemukan: "+fullpath, http.StatusNotFound)
+	if req.FlowPath == "" {
+		WriteError(w, http.StatusBadRequest, CodeValidationFailed, "flow_path wajib diisi")
 		return
 	}
 
-	// ✅ FIX: Gunakan RunFlowAndReturnOutput untuk mendapatkan hasil
-	result, err := executor.RunFlowAndReturnOutput(fullpath, req.Input)
+	executeFlow(w, r, req.FlowPath, req.Input)
+}
+
+// executeFlow adalah inti semua endpoint eksekusi flow: resolve path (flows/global
+// meng-override flows/examples), cek scope tenant API key, jalankan, tulis FlowResponse.
+// Mengembalikan response jika sukses (nil jika error sudah ditulis ke w).
+func executeFlow(w http.ResponseWriter, r *http.Request, name string, input map[string]interface{}) *FlowResponse {
+	fullpath, err := loader.ResolveFlowPath(name)
 	if err != nil {
-		http.Error(w, "❌ Gagal eksekusi flow: "+err.Error(), http.StatusInternalServerError)
-		return
+		utils.Log.Warn().Err(err).Str("flow", name).Msg("🚫 Suspicious flow name")
+		WriteError(w, http.StatusBadRequest, CodeValidationFailed, err.Error())
+		return nil
+	}
+
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+
+	// API key yang di-scope ke tenant hanya boleh menjalankan flow untuk tenant tersebut
+	if err := EnforceTenant(r.Context(), input); err != nil {
+		WriteError(w, http.StatusForbidden, CodeForbidden, err.Error())
+		return nil
+	}
+
+	utils.Log.Debug().Interface("input", input).Msg("🟡 Received Input")
+
+	executionID := executor.NewExecutionID()
+	w.Header().Set("X-Execution-ID", executionID)
+	// Root span run-flow; melanjutkan header traceparent dari caller jika ada
+	ctx, span := tracing.StartHTTP(r, "run-flow "+name)
+	defer span.End()
+	span.SetAttr("flow", name)
+	span.SetAttr("execution_id", executionID)
+
+	ctx = executor.WithExecutionID(ctx, executionID)
+	result, err := executor.RunFlowAndReturnOutputContext(ctx, fullpath, input)
+	if err != nil {
+		span.RecordError(err)
+		utils.Log.Error().Err(err).Str("execution_id", executionID).Str("flow", name).Msg("❌ Error running flow")
+		// ?partial=true: output node yang sudah sukses ikut dikembalikan bersama error
+		if partial, _ := strconv.ParseBool(r.URL.Query().Get("partial")); partial {
+			WriteFlowErrorWithPartial(w, err, executionID)
+		} else {
+			WriteFlowError(w, err, executionID)
+		}
+		return nil
 	}
 
 	utils.Log.Info().
-		Str("flow_path", req.FlowPath).
+		Str("execution_id", executionID).
+		Str("flow", name).
 		Str("fullpath", fullpath).
 		Interface("result", result).
 		Msg("✅ Flow executed successfully")
 
-	// ✅ FIX: Kirim hasil sebagai JSON response
-	w.Header().Set("Content-Type", "application/json")
-	response := map[string]interface{}{
-		"status":    "success",
-		"flow_path": req.FlowPath,
-		"result":    result,
+	resp := FlowResponse{
+		Status:      "success",
+		ExecutionID: executionID,
+		Flow:        name,
+		Result:      result,
+		Errors:      executor.TraceFromContext(ctx).Errors(),
+	}
+	if len(resp.Errors) > 0 {
+		resp.Status = "partial"
 	}
 
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, "❌ Gagal encode response: "+err.Error(), http.StatusInternalServerError)
-		return
+	body, err := redactedJSON(resp)
+	if err != nil {
+		utils.Log.Error().Err(err).Msg("❌ Error encoding JSON response")
+		return &resp
 	}
-}
\ No newline at end of file
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+	return &resp
+}
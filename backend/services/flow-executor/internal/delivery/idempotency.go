@@ -0,0 +1,110 @@
+package delivery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/statestore"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Header idempotency dari client; replay mengembalikan hasil tersimpan tanpa eksekusi ulang
+const idempotencyHeader = "Idempotency-Key"
+
+var (
+	resultStore statestore.ResultStore = statestore.NewMemoryResultStore()
+
+	// inFlight menandai key yang sedang dieksekusi di instance ini (retry paralel → 409)
+	inFlight sync.Map
+)
+
+// SetResultStore memasang store hasil idempotency; di-set dari main dengan store yang sama
+// dengan checkpoint (statestore.ResultStoreFor). nil mengembalikan ke in-memory.
+func SetResultStore(s statestore.ResultStore) {
+	if s == nil {
+		s = statestore.NewMemoryResultStore()
+	}
+	resultStore = s
+}
+
+// idempotencyTTL dari ENV IDEMPOTENCY_TTL (durasi Go), default 24h
+func idempotencyTTL() time.Duration {
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 24 * time.Hour
+}
+
+// idempotencyRecord adalah isi store: hash input request + response sukses yang di-replay
+type idempotencyRecord struct {
+	RequestHash string          `json:"request_hash"`
+	Response    json.RawMessage `json:"response"`
+}
+
+// idempotencyStoreKey di-scope ke tenant & API key pemanggil, supaya key yang sama dari
+// tenant/key lain tidak pernah me-replay response milik orang lain
+func idempotencyStoreKey(ctx context.Context, key, flow string) string {
+	return ScopedTenant(ctx) + ":" + APIKeyID(ctx) + ":" + flow + ":" + key
+}
+
+// requestHash: sha256 dari input JSON (key map terurut oleh encoding/json)
+func requestHash(input map[string]interface{}) string {
+	data, _ := json.Marshal(input)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// replayIdempotent menulis response tersimpan jika key sudah pernah sukses; true berarti sudah ditangani.
+// Key sama dengan body berbeda ditolak 422 (bukan di-replay, bukan dijalankan ulang).
+func replayIdempotent(w http.ResponseWriter, r *http.Request, storeKey, reqHash string) bool {
+	data, err := resultStore.LoadResult(r.Context(), storeKey)
+	if errors.Is(err, statestore.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		// Store down tidak boleh memblokir eksekusi; flow dijalankan seperti tanpa key
+		utils.Log.Warn().Err(err).Str("key", storeKey).Msg("⚠️ Gagal baca idempotency store")
+		return false
+	}
+
+	var rec idempotencyRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		utils.Log.Warn().Err(err).Str("key", storeKey).Msg("⚠️ Idempotency record rusak, flow dijalankan ulang")
+		return false
+	}
+	if rec.RequestHash != reqHash {
+		utils.Log.Warn().Str("key", storeKey).Msg("🚫 Idempotency-Key dipakai ulang dengan body berbeda")
+		WriteError(w, http.StatusUnprocessableEntity, CodeIdempotencyConflict, "Idempotency-Key sudah dipakai untuk request dengan body berbeda")
+		return true
+	}
+
+	utils.Log.Info().Str("key", storeKey).Msg("♻️ Idempotent replay, flow tidak dijalankan ulang")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.Write(rec.Response)
+	return true
+}
+
+// saveIdempotent menyimpan response sukses; hasil error tidak disimpan supaya retry bisa berhasil
+func saveIdempotent(ctx context.Context, storeKey, reqHash string, resp *FlowResponse) {
+	data, err := redactedJSON(resp)
+	if err != nil {
+		return
+	}
+	rec, err := json.Marshal(idempotencyRecord{RequestHash: reqHash, Response: data})
+	if err != nil {
+		return
+	}
+	if err := resultStore.SaveResult(ctx, storeKey, rec, idempotencyTTL()); err != nil {
+		utils.Log.Warn().Err(err).Str("key", storeKey).Msg("⚠️ Gagal simpan idempotency result")
+	}
+}
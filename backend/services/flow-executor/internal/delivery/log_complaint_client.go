@@ -3,29 +3,89 @@ package delivery
 import (
 	"context"
 	"fmt"
+	"os"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/milkyhoop/flow-executor/internal/gen"
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
 	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
-// LogComplaint memanggil gRPC ke complaint_service.CreateComplaint
+var (
+	complaintMu     sync.Mutex
+	complaintConn   *grpc.ClientConn
+	complaintClient pb.ComplaintServiceClient
+)
+
+// complaintTarget mengembalikan alamat complaint_service dari ENV COMPLAINT_GRPC_HOST/PORT
+func complaintTarget() string {
+	host := os.Getenv("COMPLAINT_GRPC_HOST")
+	port := os.Getenv("COMPLAINT_GRPC_PORT")
+	if host == "" {
+		host = "complaint_service"
+	}
+	if port == "" {
+		port = "5010"
+	}
+	return fmt.Sprintf("%s:%s", host, port)
+}
+
+// getComplaintClient membuat koneksi sekali lalu dipakai bersama (lazy, seperti ragclient)
+func getComplaintClient() (pb.ComplaintServiceClient, error) {
+	complaintMu.Lock()
+	defer complaintMu.Unlock()
+
+	if complaintClient != nil {
+		return complaintClient, nil
+	}
+	target := complaintTarget()
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()), tracing.DialOption())
+	if err != nil {
+		return nil, fmt.Errorf("❌ Gagal konek ke complaint_service: %w", err)
+	}
+	complaintConn = conn
+	complaintClient = pb.NewComplaintServiceClient(conn)
+	return complaintClient, nil
+}
+
+// resetComplaintClient membuang koneksi rusak supaya call berikutnya dial ulang (self-healing)
+func resetComplaintClient() {
+	complaintMu.Lock()
+	defer complaintMu.Unlock()
+
+	if complaintConn != nil {
+		complaintConn.Close()
+	}
+	complaintConn = nil
+	complaintClient = nil
+}
+
+// LogComplaint memanggil gRPC ke complaint_service.CreateComplaint dengan metadata default
 func LogComplaint(userID string, message string) (string, error) {
+	return LogComplaintWithMeta(userID, message, observer.ComplaintMeta{})
+}
+
+// LogComplaintWithMeta sama dengan LogComplaint, dengan product/source/emotion dari flow;
+// field kosong diisi default lewat ComplaintMeta.WithDefaults.
+func LogComplaintWithMeta(userID string, message string, meta observer.ComplaintMeta) (string, error) {
+	meta = meta.WithDefaults()
 	utils.Log.Info().
 		Str("user_id", userID).
 		Str("message", message).
+		Str("emotion", meta.Emotion).
 		Msg("📨 Logging complaint via gRPC")
 
-	conn, err := grpc.Dial("complaint_service:5010", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client, err := getComplaintClient()
 	if err != nil {
-		return "", fmt.Errorf("❌ Gagal konek ke complaint_service: %w", err)
+		return "", err
 	}
-	defer conn.Close()
-
-	client := pb.NewComplaintServiceClient(conn)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -33,13 +93,17 @@ func LogComplaint(userID string, message string) (string, error) {
 	req := &pb.CreateComplaintRequest{
 		UserId:  userID,
 		Message: message,
-		Product: "unknown",
-		Source:  "flow-executor",
-		Emotion: "neutral",
+		Product: meta.Product,
+		Source:  meta.Source,
+		Emotion: meta.Emotion,
 	}
 
 	resp, err := client.CreateComplaint(ctx, req)
 	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			utils.Log.Warn().Err(err).Str("target", complaintTarget()).Msg("🔁 complaint_service unavailable, koneksi di-reset")
+			resetComplaintClient()
+		}
 		return "", fmt.Errorf("❌ Gagal kirim complaint: %w", err)
 	}
 
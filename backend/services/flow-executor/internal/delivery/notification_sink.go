@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// KafkaSink mengirim event node ke topic send-notification lewat writer dari InitKafkaWriter
+type KafkaSink struct{}
+
+func (KafkaSink) Publish(key []byte, payload []byte, headers map[string]string) error {
+	return PublishNotification(key, payload, headers)
+}
+
+// WebhookSink mem-POST setiap event (JSON) ke URL; key & headers dikirim sebagai HTTP header
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+func (s WebhookSink) Publish(key []byte, payload []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Key", string(key))
+	for k, v := range headers {
+		if v != "" {
+			req.Header.Set("X-"+strings.ReplaceAll(k, "_", "-"), v)
+		}
+	}
+
+	resp, err := s.Client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			err = fmt.Errorf("webhook %s: status %d", s.URL, resp.StatusCode)
+		}
+	}
+	if err != nil {
+		observer.NotificationPublishFailures.WithLabelValues("webhook").Inc()
+		utils.Component("delivery").Warn().Err(err).Msg("⚠️ Gagal kirim event ke webhook, event di-drop")
+		return err
+	}
+	return nil
+}
+
+// kafkaSinkSelected: true jika NOTIFICATION_SINK memakai Kafka writer (kafka / default)
+func kafkaSinkSelected() bool {
+	sink := strings.ToLower(os.Getenv("NOTIFICATION_SINK"))
+	return sink == "" || sink == "kafka"
+}
+
+// NotificationSinkFromEnv memilih sink dari ENV NOTIFICATION_SINK: kafka (default), webhook, none.
+// Webhook: NOTIFICATION_WEBHOOK_URL (wajib), NOTIFICATION_WEBHOOK_TIMEOUT (default 5s).
+func NotificationSinkFromEnv() (observer.NotificationSink, error) {
+	logger := utils.Component("delivery")
+
+	switch sink := strings.ToLower(os.Getenv("NOTIFICATION_SINK")); sink {
+	case "", "kafka":
+		logger.Info().Msg("📡 Notification sink: kafka")
+		return KafkaSink{}, nil
+	case "webhook":
+		url := os.Getenv("NOTIFICATION_WEBHOOK_URL")
+		if url == "" {
+			return nil, fmt.Errorf("NOTIFICATION_SINK=webhook membutuhkan NOTIFICATION_WEBHOOK_URL")
+		}
+		timeout := 5 * time.Second
+		if v := os.Getenv("NOTIFICATION_WEBHOOK_TIMEOUT"); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				timeout = d
+			}
+		}
+		logger.Info().Str("url", url).Dur("timeout", timeout).Msg("📡 Notification sink: webhook")
+		return WebhookSink{URL: url, Client: &http.Client{Timeout: timeout}}, nil
+	case "none":
+		logger.Info().Msg("📡 Notification sink: none, event node tidak dikirim")
+		return observer.NoopSink{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported NOTIFICATION_SINK: %s", sink)
+	}
+}
@@ -0,0 +1,161 @@
+package delivery
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// rateLimitExemptPaths tidak kena rate limit (probe & scrape)
+var rateLimitExemptPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// tokenBucket: isi ulang rate token/detik sampai burst; satu request = satu token
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// take mengambil satu token; jika habis, mengembalikan waktu tunggu sampai token berikutnya
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := (1 - b.tokens) / b.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// RateLimiter adalah token bucket global + (opsional) per IP, dibuat lewat RateLimiterFromEnv
+type RateLimiter struct {
+	mu     sync.Mutex
+	global *tokenBucket
+
+	ipRate  float64
+	ipBurst int
+	perIP   map[string]*tokenBucket
+	lastGC  time.Time
+}
+
+// RateLimiterFromEnv: RATE_LIMIT_RPS/RATE_LIMIT_BURST (global, default 50/100),
+// RATE_LIMIT_PER_IP_RPS/RATE_LIMIT_PER_IP_BURST (default 0 = nonaktif).
+// Mengembalikan nil (tanpa limit) jika kedua RPS 0.
+func RateLimiterFromEnv() *RateLimiter {
+	rps := envFloat("RATE_LIMIT_RPS", 50)
+	burst := envInt("RATE_LIMIT_BURST", 100)
+	ipRPS := envFloat("RATE_LIMIT_PER_IP_RPS", 0)
+	ipBurst := envInt("RATE_LIMIT_PER_IP_BURST", int(math.Ceil(ipRPS*2)))
+
+	if rps <= 0 && ipRPS <= 0 {
+		utils.Component("delivery").Warn().Msg("⚠️ RATE_LIMIT_RPS=0, rate limiter nonaktif")
+		return nil
+	}
+
+	now := time.Now()
+	rl := &RateLimiter{ipRate: ipRPS, ipBurst: ipBurst, perIP: map[string]*tokenBucket{}, lastGC: now}
+	if rps > 0 {
+		rl.global = newTokenBucket(rps, burst, now)
+	}
+
+	utils.Component("delivery").Info().
+		Float64("rps", rps).
+		Int("burst", burst).
+		Float64("per_ip_rps", ipRPS).
+		Int("per_ip_burst", ipBurst).
+		Msg("🚦 Rate limiter aktif")
+	return rl
+}
+
+// allow memeriksa bucket per IP dulu (supaya satu IP tidak menghabiskan token global)
+func (rl *RateLimiter) allow(ip string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	now := time.Now()
+
+	if rl.ipRate > 0 && rl.ipBurst > 0 {
+		rl.gcIdle(now)
+		b, ok := rl.perIP[ip]
+		if !ok {
+			b = newTokenBucket(rl.ipRate, rl.ipBurst, now)
+			rl.perIP[ip] = b
+		}
+		if ok, wait := b.take(now); !ok {
+			return false, wait
+		}
+	}
+	if rl.global != nil {
+		return rl.global.take(now)
+	}
+	return true, 0
+}
+
+// gcIdle membuang bucket IP yang sudah penuh lagi (idle) supaya map tidak tumbuh tanpa batas
+func (rl *RateLimiter) gcIdle(now time.Time) {
+	if now.Sub(rl.lastGC) < time.Minute {
+		return
+	}
+	rl.lastGC = now
+	idle := time.Duration(float64(rl.ipBurst) / rl.ipRate * float64(time.Second))
+	for ip, b := range rl.perIP {
+		if now.Sub(b.last) > idle {
+			delete(rl.perIP, ip)
+		}
+	}
+}
+
+// RateLimit membungkus handler: request yang melebihi limit ditolak 429 + Retry-After
+func RateLimit(rl *RateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rl == nil || rateLimitExemptPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		if ok, wait := rl.allow(ip); !ok {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			utils.Log.Warn().Str("ip", ip).Str("path", r.URL.Path).Msg("🚦 Rate limit exceeded")
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			WriteError(w, http.StatusTooManyRequests, CodeRateLimited, "rate limit exceeded")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func envFloat(name string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(name), 64); err == nil && v >= 0 {
+		return v
+	}
+	return def
+}
+
+func envInt(name string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(name)); err == nil && v >= 0 {
+		return v
+	}
+	return def
+}
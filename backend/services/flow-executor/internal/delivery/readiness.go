@@ -0,0 +1,135 @@
+package delivery
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+)
+
+// Timeout per dependency untuk readiness check
+const readinessTimeout = 2 * time.Second
+
+// CheckReadiness memeriksa Kafka writer (hanya jika NOTIFICATION_SINK=kafka), health gRPC
+// upstream (lihat CheckDependencyHealth), dan hasil self-test startup jika SELFTEST_FLOW di-set.
+// Mengembalikan status per dependency ("ok" atau pesan error) dan true jika semua ok.
+func CheckReadiness(ctx context.Context) (map[string]string, bool) {
+	results := CheckDependencyHealth(ctx)
+	// Sink webhook/none tidak butuh Kafka, jadi writer kosong bukan alasan not-ready
+	if kafkaSinkSelected() {
+		if KafkaWriterReady() {
+			results["kafka"] = "ok"
+		} else {
+			results["kafka"] = "writer not initialized"
+		}
+	}
+	// Self-test SELFTEST_FLOW (jika dikonfigurasi) harus sukses sebelum ready
+	if st := SelfTestStatus(); st != "" {
+		results["selftest"] = st
+	}
+
+	ready := true
+	for _, r := range results {
+		if r != "ok" {
+			ready = false
+		}
+	}
+	return results, ready
+}
+
+var (
+	healthCacheMu      sync.Mutex
+	healthCache        map[string]string
+	healthCacheExpires time.Time
+)
+
+// dependencyHealthTTL: lama hasil health check di-cache (ENV DEPENDENCY_HEALTH_CACHE_TTL, default 5s)
+func dependencyHealthTTL() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("DEPENDENCY_HEALTH_CACHE_TTL")); err == nil && d >= 0 {
+		return d
+	}
+	return 5 * time.Second
+}
+
+// CheckDependencyHealth memanggil grpc.health.v1 Check ke RAG LLM, RAG CRUD, compiler,
+// complaint, dan tenant-manager secara paralel. Hasil di-cache singkat supaya probe
+// yang sering tidak membanjiri upstream.
+func CheckDependencyHealth(ctx context.Context) map[string]string {
+	healthCacheMu.Lock()
+	defer healthCacheMu.Unlock()
+
+	if healthCache == nil || time.Now().After(healthCacheExpires) {
+		healthCache = checkDependencies(ctx)
+		healthCacheExpires = time.Now().Add(dependencyHealthTTL())
+	}
+
+	// Salinan supaya caller bebas menambah entry (mis. kafka)
+	results := make(map[string]string, len(healthCache)+1)
+	for k, v := range healthCache {
+		results[k] = v
+	}
+	return results
+}
+
+func checkDependencies(ctx context.Context) map[string]string {
+	targets := map[string]string{
+		"ragllm":         observer.RagLLMTarget(),
+		"ragcrud":        ragclient.RagCrudTarget(),
+		"compiler":       compilerTarget(),
+		"complaint":      complaintTarget(),
+		"tenant_manager": tenantManagerTarget(),
+	}
+
+	results := map[string]string{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, target := range targets {
+		wg.Add(1)
+		go func(name, target string) {
+			defer wg.Done()
+			result := "ok"
+			if err := pingGRPCHealth(ctx, target); err != nil {
+				result = err.Error()
+			}
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name, target)
+	}
+	wg.Wait()
+	return results
+}
+
+// pingGRPCHealth memanggil grpc.health.v1 Check; service tanpa health server
+// (Unimplemented) tetap dianggap up karena koneksi berhasil.
+func pingGRPCHealth(ctx context.Context, target string) error {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return status.Errorf(codes.Unavailable, "health status %s", resp.GetStatus())
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/loader"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Status self-test yang dilaporkan ke /readyz
+const (
+	selfTestPending = "pending"
+	selfTestOK      = "ok"
+)
+
+var (
+	selfTestMu     sync.Mutex
+	selfTestStatus string // "" = self-test tidak dikonfigurasi
+)
+
+// SelfTestFlow adalah flow smoke test dari ENV SELFTEST_FLOW (nama file di flows/examples
+// atau flows/global); kosong = self-test nonaktif
+func SelfTestFlow() string {
+	return os.Getenv("SELFTEST_FLOW")
+}
+
+// selfTestTimeout dari ENV SELFTEST_TIMEOUT (default 30s)
+func selfTestTimeout() time.Duration {
+	if d, err := time.ParseDuration(os.Getenv("SELFTEST_TIMEOUT")); err == nil && d > 0 {
+		return d
+	}
+	return 30 * time.Second
+}
+
+// RunSelfTest menjalankan SELFTEST_FLOW sekali dengan input dari ENV SELFTEST_INPUT (JSON, opsional).
+// Saat gagal, node yang gagal + kode error + health tiap dependency di-log supaya kelihatan
+// dependency mana yang rusak (mis. typo host RAG). Hasilnya juga dilaporkan di /readyz.
+func RunSelfTest(ctx context.Context) error {
+	name := SelfTestFlow()
+	if name == "" {
+		err := errors.New("SELFTEST_FLOW tidak di-set")
+		utils.Component("selftest").Error().Err(err).Msg("❌ Self-test tidak bisa dijalankan")
+		return err
+	}
+	setSelfTestStatus(selfTestPending)
+	logger := utils.Component("selftest").With().Str("flow", name).Logger()
+
+	err := runSelfTestFlow(ctx, name)
+	if err == nil {
+		setSelfTestStatus(selfTestOK)
+		logger.Info().Msg("✅ Self-test flow sukses")
+		return nil
+	}
+	setSelfTestStatus(err.Error())
+
+	code, _ := ClassifyFlowError(err)
+	event := logger.Error().Err(err).Str("code", code)
+	var pe *executor.PartialError
+	if errors.As(err, &pe) {
+		event = event.Str("failed_node", pe.FailedNode)
+	}
+	event.Interface("dependencies", CheckDependencyHealth(ctx)).Msg("❌ Self-test flow gagal")
+	return err
+}
+
+func runSelfTestFlow(ctx context.Context, name string) error {
+	fullpath, err := loader.ResolveFlowPath(name)
+	if err != nil {
+		return err
+	}
+
+	input := map[string]interface{}{}
+	if raw := os.Getenv("SELFTEST_INPUT"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &input); err != nil {
+			return fmt.Errorf("SELFTEST_INPUT bukan JSON object: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, selfTestTimeout())
+	defer cancel()
+	ctx = executor.WithExecutionID(ctx, executor.NewExecutionID())
+	_, err = executor.RunFlowAndReturnOutputContext(ctx, fullpath, input)
+	return err
+}
+
+func setSelfTestStatus(status string) {
+	selfTestMu.Lock()
+	defer selfTestMu.Unlock()
+	selfTestStatus = status
+}
+
+// SelfTestStatus: "" jika self-test tidak dijalankan, "pending", "ok", atau pesan error
+func SelfTestStatus() string {
+	selfTestMu.Lock()
+	defer selfTestMu.Unlock()
+	return selfTestStatus
+}
@@ -2,40 +2,158 @@ package delivery
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
+	"github.com/milkyhoop/flow-executor/internal/executor"
 	pb "github.com/milkyhoop/flow-executor/internal/proto/tenant_manager"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// ListTenants memanggil gRPC ke TenantManager service untuk mengambil daftar tenant.
-func ListTenants() {
+var (
+	tenantConn     *grpc.ClientConn
+	tenantConnOnce sync.Once
+	tenantConnErr  error
+
+	tenantCacheMu sync.Mutex
+	tenantCache   = map[string]tenantCacheEntry{}
+)
+
+type tenantCacheEntry struct {
+	tenant  *executor.TenantConfig
+	expires time.Time
+}
+
+// tenantManagerTarget mengembalikan alamat TenantManager dari ENV TENANT_MANAGER_HOST
+func tenantManagerTarget() string {
 	host := os.Getenv("TENANT_MANAGER_HOST")
 	if host == "" {
 		host = "localhost:5000" // default Docker Compose
 	}
+	return host
+}
 
-	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		log.Fatalf("❌ Gagal konek tenant manager: %v", err)
+func getTenantManagerClient() (pb.TenantManagerClient, error) {
+	tenantConnOnce.Do(func() {
+		tenantConn, tenantConnErr = grpc.NewClient(tenantManagerTarget(), grpc.WithTransportCredentials(insecure.NewCredentials()), tracing.DialOption())
+	})
+	if tenantConnErr != nil {
+		return nil, fmt.Errorf("❌ Gagal konek tenant manager: %w", tenantConnErr)
 	}
-	defer conn.Close()
+	return pb.NewTenantManagerClient(tenantConn), nil
+}
 
-	client := pb.NewTenantManagerClient(conn)
+// ListTenants memanggil gRPC ke TenantManager service untuk mengambil daftar tenant.
+func ListTenants(ctx context.Context) ([]*pb.Tenant, error) {
+	client, err := getTenantManagerClient()
+	if err != nil {
+		return nil, err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// RPC request pakai google.protobuf.Empty{}
 	res, err := client.ListTenants(ctx, &emptypb.Empty{})
 	if err != nil {
-		log.Fatalf("❌ Error ListTenants: %v", err)
+		return nil, fmt.Errorf("❌ Error ListTenants: %w", err)
+	}
+	return res.GetTenants(), nil
+}
+
+// GetTenant mengambil tenant dari TenantManager (by alias) dan mengembalikan config-nya.
+// Tenant unknown/disabled → error yang membungkus executor.ErrTenantForbidden.
+// Hasil di-cache selama TENANT_CACHE_TTL (default 60s) supaya tidak ada RPC per eksekusi.
+func GetTenant(ctx context.Context, tenantID string) (*executor.TenantConfig, error) {
+	tenantCacheMu.Lock()
+	if entry, ok := tenantCache[tenantID]; ok && time.Now().Before(entry.expires) {
+		tenantCacheMu.Unlock()
+		return entry.tenant, nil
+	}
+	tenantCacheMu.Unlock()
+
+	client, err := getTenantManagerClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := client.GetTenant(ctx, &pb.GetTenantRequest{Alias: tenantID})
+	if status.Code(err) == codes.NotFound || (err == nil && res.GetTenant() == nil) {
+		return nil, fmt.Errorf("unknown tenant: %w", executor.ErrTenantForbidden)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("❌ Error GetTenant: %w", err)
+	}
+
+	t := res.GetTenant()
+	if s := strings.ToLower(t.GetStatus()); s != "" && s != "active" {
+		return nil, fmt.Errorf("tenant is %s: %w", s, executor.ErrTenantForbidden)
+	}
+
+	tenant := &executor.TenantConfig{
+		ID:          t.GetId(),
+		Alias:       t.GetAlias(),
+		DisplayName: t.GetDisplayName(),
+		Status:      t.GetStatus(),
+	}
+	loadTenantSettings(tenant)
+
+	tenantCacheMu.Lock()
+	tenantCache[tenantID] = tenantCacheEntry{tenant: tenant, expires: time.Now().Add(tenantCacheTTL())}
+	tenantCacheMu.Unlock()
+
+	utils.Component("delivery").Debug().Str("tenant_id", tenantID).Msg("🏢 Tenant config loaded")
+	return tenant, nil
+}
+
+// loadTenantSettings: TenantManager belum menyimpan setting eksekusi, jadi diambil dari ENV
+// TENANT_<ALIAS>_CHANNELS / _RATE_LIMIT / _MAX_CONCURRENT_FLOWS, plus default tuning RAG
+// _RAG_SIMILARITY_THRESHOLD / _RAG_TOP_K / _RAG_MAX_CONTENT_LENGTH.
+func loadTenantSettings(tenant *executor.TenantConfig) {
+	prefix := "TENANT_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(tenant.Alias)) + "_"
+	if v := os.Getenv(prefix + "CHANNELS"); v != "" {
+		for _, c := range strings.Split(v, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				tenant.EnabledChannels = append(tenant.EnabledChannels, c)
+			}
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv(prefix + "RATE_LIMIT")); err == nil && n > 0 {
+		tenant.RateLimitPerMinute = n
+	}
+	if n, err := strconv.Atoi(os.Getenv(prefix + "MAX_CONCURRENT_FLOWS")); err == nil && n > 0 {
+		tenant.MaxConcurrentFlows = n
 	}
+	if v, err := strconv.ParseFloat(os.Getenv(prefix+"RAG_SIMILARITY_THRESHOLD"), 64); err == nil && v > 0 {
+		tenant.RagSimilarityThreshold = v
+	}
+	if n, err := strconv.Atoi(os.Getenv(prefix + "RAG_TOP_K")); err == nil && n > 0 {
+		tenant.RagTopK = n
+	}
+	if n, err := strconv.Atoi(os.Getenv(prefix + "RAG_MAX_CONTENT_LENGTH")); err == nil && n > 0 {
+		tenant.RagMaxContentLength = n
+	}
+}
 
-	log.Printf("✅ Tenants: %v\n", res.Tenants)
+func tenantCacheTTL() time.Duration {
+	if v := os.Getenv("TENANT_CACHE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 60 * time.Second
 }
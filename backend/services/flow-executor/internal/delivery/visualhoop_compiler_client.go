@@ -2,7 +2,6 @@ package delivery
 
 import (
 	"context"
-	"log"
 	"os"
 	"time"
 
@@ -10,18 +9,27 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	pb "github.com/milkyhoop/flow-executor/internal/proto/visualhoop_compiler"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
-// CompileJSON memanggil VisualhoopCompiler gRPC service untuk compile JSON ke .pb
-func CompileJSON(jsonPath, outputPath string) error {
-	// Ambil host Visualhoop-Compiler dari ENV (untuk mode lokal/testing)
+// compilerTarget mengembalikan alamat Visualhoop-Compiler dari ENV (untuk mode lokal/testing)
+func compilerTarget() string {
 	host := os.Getenv("VISUALHOOP_COMPILER_HOST")
 	if host == "" {
 		host = "visualhoop-compiler:5001" // default Docker Compose
 	}
+	return host
+}
 
-	// Dial ke service Visualhoop-Compiler
-	conn, err := grpc.Dial(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// dialCompiler membuka koneksi ke service Visualhoop-Compiler
+func dialCompiler() (*grpc.ClientConn, error) {
+	return grpc.Dial(compilerTarget(), grpc.WithTransportCredentials(insecure.NewCredentials()), tracing.DialOption())
+}
+
+// CompileJSON memanggil VisualhoopCompiler gRPC service untuk compile JSON ke .pb
+func CompileJSON(jsonPath, outputPath string) error {
+	conn, err := dialCompiler()
 	if err != nil {
 		return err
 	}
@@ -45,6 +53,29 @@ func CompileJSON(jsonPath, outputPath string) error {
 		return err
 	}
 
-	log.Printf("✅ Visualhoop-Compiler Response: %s", resp.GetMessage())
+	utils.Component("delivery").Info().Str("message", resp.GetMessage()).Msg("✅ Visualhoop-Compiler Response")
 	return nil
 }
+
+// CompileBytes mengirim JSON flow inline ke Visualhoop-Compiler dan mengembalikan bytes .pb,
+// dipakai untuk flow in-memory (mis. dari visual editor) tanpa shared volume.
+func CompileBytes(jsonData []byte) ([]byte, error) {
+	conn, err := dialCompiler()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := pb.NewVisualhoopCompilerClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.CompileBytes(ctx, &pb.CompileBytesRequest{Json: jsonData})
+	if err != nil {
+		return nil, err
+	}
+
+	utils.Component("delivery").Info().Int("size", len(resp.GetPb())).Msg("✅ Flow compiled in-memory via Visualhoop-Compiler")
+	return resp.GetPb(), nil
+}
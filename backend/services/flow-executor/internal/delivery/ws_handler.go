@@ -0,0 +1,187 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/loader"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// wsInputTimeout: batas waktu client mengirim frame input pertama setelah connect
+const wsInputTimeout = 10 * time.Second
+
+// wsFrame adalah frame server → client: "node" per node selesai, lalu satu "completed" atau "error"
+type wsFrame struct {
+	Type        string                 `json:"type"`
+	ExecutionID string                 `json:"execution_id"`
+	Flow        string                 `json:"flow,omitempty"`
+	NodeID      string                 `json:"node_id,omitempty"`
+	Hoop        string                 `json:"hoop,omitempty"`
+	Output      map[string]interface{} `json:"output,omitempty"`
+	Status      string                 `json:"status,omitempty"`
+	Result      map[string]interface{} `json:"result,omitempty"`
+	Errors      []executor.NodeError   `json:"errors,omitempty"`
+	Code        string                 `json:"code,omitempty"`
+	Message     string                 `json:"message,omitempty"`
+}
+
+// HandleRunFlowWS menangani GET /ws/run-flow/{name}: client mengirim satu frame JSON input
+// (boleh {}), lalu menerima frame "node" per node dan satu frame akhir "completed"/"error".
+// Client disconnect meng-cancel ctx flow. API key lewat X-API-Key, subprotocol "api-key.<key>"
+// atau ?api_key= (lihat requestAPIKey); Origin browser harus ada di WS_ALLOWED_ORIGINS.
+var HandleRunFlowWS http.Handler = websocket.Server{
+	Handshake: wsHandshake,
+	Handler:   runFlowWS,
+}
+
+// wsAllowedOrigins dari ENV WS_ALLOWED_ORIGINS (dipisah koma, "*" = semua origin).
+// Kosong = tidak ada origin browser yang diizinkan.
+func wsAllowedOrigins() []string {
+	var origins []string
+	for _, o := range strings.Split(os.Getenv("WS_ALLOWED_ORIGINS"), ",") {
+		if o = strings.TrimRight(strings.TrimSpace(o), "/"); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+// wsHandshake menolak Origin di luar allowlist (cegah cross-site WebSocket hijacking) dan
+// memilih satu subprotocol untuk dibalas. Request tanpa Origin (client non-browser) diizinkan,
+// karena tetap wajib membawa API key.
+func wsHandshake(config *websocket.Config, r *http.Request) error {
+	if origin := strings.TrimRight(r.Header.Get("Origin"), "/"); origin != "" {
+		allowed := false
+		for _, o := range wsAllowedOrigins() {
+			if o == "*" || strings.EqualFold(o, origin) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			utils.Log.Warn().Str("origin", origin).Str("path", r.URL.Path).Msg("🚫 WebSocket origin not allowed")
+			return fmt.Errorf("origin %q not allowed", origin)
+		}
+	}
+
+	// Browser menolak koneksi jika subprotocol yang ditawarkan tidak dibalas; prioritaskan
+	// subprotocol aplikasi supaya API key tidak perlu dipantulkan balik
+	var keyProtocol string
+	for _, p := range config.Protocol {
+		if !strings.HasPrefix(p, wsAPIKeyProtocol) {
+			config.Protocol = []string{p}
+			return nil
+		}
+		keyProtocol = p
+	}
+	if keyProtocol != "" {
+		config.Protocol = []string{keyProtocol}
+	}
+	return nil
+}
+
+func runFlowWS(ws *websocket.Conn) {
+	defer ws.Close()
+	r := ws.Request()
+	name := strings.TrimPrefix(r.URL.Path, "/ws/run-flow/")
+
+	executionID := executor.NewExecutionID()
+	// Frame melewati Redact seperti event Kafka: secret hasil render template tidak boleh ke client
+	send := func(f wsFrame) {
+		f.ExecutionID = executionID
+		b, err := json.Marshal(f)
+		if err != nil {
+			utils.Log.Error().Err(err).Str("execution_id", executionID).Msg("❌ Gagal encode frame WebSocket")
+			return
+		}
+		if err := websocket.Message.Send(ws, utils.Redact(string(b))); err != nil {
+			utils.Log.Debug().Err(err).Str("execution_id", executionID).Msg("⚠️ Gagal kirim frame WebSocket")
+		}
+	}
+	sendError := func(code, message string) {
+		send(wsFrame{Type: "error", Flow: name, Code: code, Message: message})
+	}
+
+	// WriteTimeout server juga berlaku untuk koneksi yang di-hijack; stream bisa lebih lama
+	ws.SetWriteDeadline(time.Time{})
+	ws.SetReadDeadline(time.Now().Add(wsInputTimeout))
+	var input map[string]interface{}
+	if err := websocket.JSON.Receive(ws, &input); err != nil {
+		sendError(CodeValidationFailed, "frame pertama harus JSON input flow: "+err.Error())
+		return
+	}
+	ws.SetReadDeadline(time.Time{})
+
+	fullpath, err := loader.ResolveFlowPath(name)
+	if err != nil {
+		utils.Log.Warn().Err(err).Str("flow", name).Msg("🚫 Suspicious flow name")
+		sendError(CodeValidationFailed, err.Error())
+		return
+	}
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+	if err := EnforceTenant(r.Context(), input); err != nil {
+		sendError(CodeForbidden, err.Error())
+		return
+	}
+
+	ctx, span := tracing.StartHTTP(r, "run-flow-ws "+name)
+	defer span.End()
+	span.SetAttr("flow", name)
+	span.SetAttr("execution_id", executionID)
+
+	// Client disconnect (read error) → cancel flow, node berikutnya tidak dijalankan
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		var discard []byte
+		for {
+			if err := websocket.Message.Receive(ws, &discard); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	ctx = executor.WithExecutionID(ctx, executionID)
+	ctx = executor.WithEventCallback(ctx, func(e executor.NodeEvent) {
+		send(wsFrame{Type: "node", NodeID: e.NodeID, Hoop: e.Hoop, Output: e.Output})
+	})
+
+	utils.Log.Info().Str("execution_id", executionID).Str("flow", name).Msg("🔌 WebSocket run-flow started")
+	result, err := executor.RunFlowAndReturnOutputContext(ctx, fullpath, input)
+	if err != nil {
+		span.RecordError(err)
+		if ctx.Err() != nil {
+			utils.Log.Warn().Str("execution_id", executionID).Str("flow", name).Msg("🔌 WebSocket client disconnected, flow cancelled")
+			return
+		}
+		utils.Log.Error().Err(err).Str("execution_id", executionID).Str("flow", name).Msg("❌ Error running flow")
+		code, _ := ClassifyFlowError(err)
+		sendError(code, err.Error())
+		return
+	}
+
+	frame := wsFrame{
+		Type:   "completed",
+		Flow:   name,
+		Status: "success",
+		Result: result,
+		Errors: executor.TraceFromContext(ctx).Errors(),
+	}
+	if len(frame.Errors) > 0 {
+		frame.Status = "partial"
+	}
+	send(frame)
+}
@@ -0,0 +1,136 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/milkyhoop/flow-executor/internal/statestore"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// stateStore default noop; di-set dari main via SetStateStore(statestore.FromEnv())
+var stateStore statestore.StateStore = statestore.NoopStore{}
+
+// SetStateStore memasang store checkpoint; nil mengembalikan ke noop
+func SetStateStore(s statestore.StateStore) {
+	if s == nil {
+		s = statestore.NoopStore{}
+	}
+	stateStore = s
+}
+
+// checkpoint adalah state yang cukup untuk melanjutkan flow dari node berikutnya
+type checkpoint struct {
+	Flow       FlowSpec                          `json:"flow"`
+	NextNodeID string                            `json:"next_node_id"`
+	Step       int                               `json:"step"`
+	Outputs    map[string]map[string]interface{} `json:"outputs"`
+}
+
+// checkpointEnabled: false jika store masih NoopStore, supaya engine tidak
+// marshal seluruh flow + outputs di setiap node hanya untuk dibuang
+func checkpointEnabled() bool {
+	_, noop := stateStore.(statestore.NoopStore)
+	return !noop
+}
+
+// saveCheckpoint gagal simpan tidak menggagalkan flow, cukup di-log
+func saveCheckpoint(ctx context.Context, flow FlowSpec, outputs map[string]map[string]interface{}, nextID string, step int) {
+	if !checkpointEnabled() {
+		return
+	}
+	data, err := json.Marshal(checkpoint{Flow: flow, NextNodeID: nextID, Step: step, Outputs: outputs})
+	if err == nil {
+		// Secret hasil render tidak boleh tersimpan di store; resume me-resolve ulang dari Parameters
+		err = stateStore.SaveCheckpoint(ctx, flow.Context.ExecutionID, []byte(utils.Redact(string(data))))
+	}
+	if err != nil {
+		utils.FromContext(ctx).Warn().Err(err).Msg("⚠️ Gagal simpan checkpoint")
+	}
+}
+
+func deleteCheckpoint(ctx context.Context, flow FlowSpec) {
+	if !checkpointEnabled() {
+		return
+	}
+	if err := stateStore.DeleteCheckpoint(ctx, flow.Context.ExecutionID); err != nil {
+		utils.FromContext(ctx).Warn().Err(err).Msg("⚠️ Gagal hapus checkpoint")
+	}
+}
+
+// ErrResumeInProgress: checkpoint execution_id sedang di-resume request lain
+var ErrResumeInProgress = errors.New("resume already in progress")
+
+// ResumeFlow memuat checkpoint terakhir execution_id dan melanjutkan dari node berikutnya.
+// scopedTenant (tenant API key pemanggil, kosong = admin) harus sama dengan tenant checkpoint;
+// beda tenant diperlakukan sebagai checkpoint tidak ada supaya execution_id tenant lain tidak bocor.
+func ResumeFlow(ctx context.Context, executionID, scopedTenant string) (map[string]interface{}, error) {
+	notFound := fmt.Errorf("no checkpoint for execution %s: %w", executionID, statestore.ErrNotFound)
+
+	// Klaim dulu sebelum load supaya dua resume paralel tidak sama-sama menjalankan sisa node
+	claimed, err := stateStore.ClaimCheckpoint(ctx, executionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim checkpoint: %w", err)
+	}
+	if !claimed {
+		return nil, fmt.Errorf("execution %s: %w", executionID, ErrResumeInProgress)
+	}
+	defer func() {
+		if err := stateStore.ReleaseCheckpoint(context.WithoutCancel(ctx), executionID); err != nil {
+			utils.FromContext(ctx).Warn().Err(err).Str("execution_id", executionID).Msg("⚠️ Gagal lepas lock resume")
+		}
+	}()
+
+	data, err := stateStore.LoadCheckpoint(ctx, executionID)
+	if errors.Is(err, statestore.ErrNotFound) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	flow := cp.Flow
+	if scopedTenant != "" && flow.Context.TenantID != scopedTenant {
+		utils.Log.Warn().Str("execution_id", executionID).Str("tenant_id", scopedTenant).Msg("🚫 Resume checkpoint milik tenant lain ditolak")
+		return nil, notFound
+	}
+	if cp.Outputs == nil {
+		cp.Outputs = make(map[string]map[string]interface{})
+	}
+
+	// Validasi ulang seperti prepareRun: hoop bisa sudah di-unregister dan tenant bisa sudah dinonaktifkan
+	if err := checkHoops(flow); err != nil {
+		return nil, err
+	}
+	if err := loadTenant(ctx, &flow); err != nil {
+		return nil, err
+	}
+	release, err := acquireTenantSlot(&flow)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	logger := utils.Log.With().
+		Str("execution_id", flow.Context.ExecutionID).
+		Str("flow_id", flow.FlowID).
+		Logger()
+	ctx = logger.WithContext(ctx)
+
+	if cp.NextNodeID == "" {
+		// Crash terjadi setelah node terakhir selesai, tinggal bersihkan checkpoint
+		logger.Info().Msg("✅ Flow sudah selesai sebelum resume")
+		deleteCheckpoint(ctx, flow)
+		return nil, nil
+	}
+
+	logger.Info().Str("next_node_id", cp.NextNodeID).Int("step", cp.Step).Msg("⏯️ Resuming Flow from checkpoint")
+	_, lastOutput, err := execute(ctx, flow, cp.NextNodeID, cp.Outputs, cp.Step)
+	return lastOutput, err
+}
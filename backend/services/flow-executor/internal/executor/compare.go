@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrUncomparable: operand == berupa map/slice (== pada interface berisi map akan panic)
+var ErrUncomparable = errors.New("cannot compare composite types with ==; use exists/contains")
+
+// isComposite: map, slice, dan func tidak bisa dibandingkan dengan == di Go
+func isComposite(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	switch reflect.TypeOf(v).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func:
+		return true
+	}
+	return false
+}
+
+// ifEquals adalah operator == IfNode. Bool dan null dibandingkan secara semantik karena
+// template bisa mengubahnya jadi string: true == "true", nil == "null", false == "False".
+func ifEquals(a, b interface{}) (bool, error) {
+	if isComposite(a) || isComposite(b) {
+		return false, ErrUncomparable
+	}
+	if isNullValue(a) || isNullValue(b) {
+		return isNullValue(a) && isNullValue(b), nil
+	}
+	if ab, ok := asBool(a); ok {
+		if bb, ok := asBool(b); ok {
+			return ab == bb, nil
+		}
+	}
+	return a == b, nil
+}
+
+// isNullValue: nil atau string "null" (hasil template dari nilai JSON null)
+func isNullValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && strings.EqualFold(strings.TrimSpace(s), "null")
+}
+
+// asBool menerima bool asli atau string "true"/"false" (case-insensitive)
+func asBool(v interface{}) (bool, bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case string:
+		switch strings.ToLower(strings.TrimSpace(b)) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,17 @@
+package executor
+
+import "github.com/milkyhoop/flow-executor/internal/observer"
+
+// ComplaintLogger mengirim complaint dan mengembalikan complaint_id
+type ComplaintLogger func(userID, message string, meta observer.ComplaintMeta) (string, error)
+
+// complaintLogger default ke dummy observer; main meng-inject client gRPC (delivery.LogComplaintWithMeta)
+var complaintLogger ComplaintLogger = observer.LogComplaint
+
+// SetComplaintLogger memasang pengirim complaint; nil kembali ke dummy observer
+func SetComplaintLogger(l ComplaintLogger) {
+	if l == nil {
+		l = observer.LogComplaint
+	}
+	complaintLogger = l
+}
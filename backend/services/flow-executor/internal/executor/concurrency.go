@@ -0,0 +1,62 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/milkyhoop/flow-executor/internal/observer"
+)
+
+// ErrTenantConcurrencyLimit: tenant sedang menjalankan flow sebanyak batasnya (di-map ke HTTP 429)
+var ErrTenantConcurrencyLimit = errors.New("tenant concurrency limit exceeded")
+
+var (
+	tenantActiveMu sync.Mutex
+	tenantActive   = map[string]int{}
+)
+
+// defaultTenantConcurrency dari ENV TENANT_MAX_CONCURRENT_FLOWS (default 20, 0 = tanpa batas)
+func defaultTenantConcurrency() int {
+	if v := os.Getenv("TENANT_MAX_CONCURRENT_FLOWS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return 20
+}
+
+// acquireTenantSlot mengambil slot eksekusi untuk tenant flow; langsung ditolak (bukan antre)
+// jika batas tercapai. Batas dari config tenant (TenantManager), fallback ke default global.
+func acquireTenantSlot(flow *FlowSpec) (func(), error) {
+	tenantID := flow.Context.TenantID
+	if tenantID == "" {
+		return func() {}, nil
+	}
+
+	limit := defaultTenantConcurrency()
+	if flow.Context.Tenant != nil && flow.Context.Tenant.MaxConcurrentFlows > 0 {
+		limit = flow.Context.Tenant.MaxConcurrentFlows
+	}
+	if limit == 0 {
+		return func() {}, nil
+	}
+
+	tenantActiveMu.Lock()
+	defer tenantActiveMu.Unlock()
+	if tenantActive[tenantID] >= limit {
+		observer.FlowsRejectedConcurrency.WithLabelValues(observer.TenantLabel(tenantID)).Inc()
+		return nil, fmt.Errorf("tenant %s: %d flows running: %w", tenantID, limit, ErrTenantConcurrencyLimit)
+	}
+	tenantActive[tenantID]++
+
+	return func() {
+		tenantActiveMu.Lock()
+		defer tenantActiveMu.Unlock()
+		if tenantActive[tenantID]--; tenantActive[tenantID] <= 0 {
+			delete(tenantActive, tenantID)
+		}
+	}, nil
+}
@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlowToDOT meng-export DAG flow sebagai Graphviz DOT untuk dokumentasi/debugging.
+// Edge: fall-through (hitam), TruePath (hijau), FalsePath (merah), JumpTo (putus-putus).
+func FlowToDOT(flow FlowSpec) string {
+	var b strings.Builder
+	name := flow.FlowID
+	if name == "" {
+		name = "flow"
+	}
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(name))
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, n := range flow.Nodes {
+		label := n.ID
+		if n.Hoop != "" {
+			label += "\\n" + n.Hoop
+		}
+		shape := ""
+		if n.Hoop == "IfNode" {
+			shape = ", shape=diamond, style=solid"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", dotQuote(n.ID), dotQuote(label), shape)
+	}
+
+	for i, n := range flow.Nodes {
+		// Fall-through sama dengan runtime: node tanpa TruePath lanjut ke node berikutnya
+		if n.Hoop != "IfNode" && n.TruePath == "" && i+1 < len(flow.Nodes) {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(n.ID), dotQuote(flow.Nodes[i+1].ID))
+		}
+		if n.TruePath != "" {
+			fmt.Fprintf(&b, "  %s -> %s [color=green, label=\"true\"];\n", dotQuote(n.ID), dotQuote(n.TruePath))
+		}
+		if n.FalsePath != "" {
+			fmt.Fprintf(&b, "  %s -> %s [color=red, label=\"false\"];\n", dotQuote(n.ID), dotQuote(n.FalsePath))
+		}
+		if n.JumpTo != "" {
+			fmt.Fprintf(&b, "  %s -> %s [style=dashed, label=\"jump\"];\n", dotQuote(n.ID), dotQuote(n.JumpTo))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote membuat ID/label DOT yang aman (quote + escape)
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -1,23 +1,44 @@
 package executor
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/milkyhoop/flow-executor/internal/loader"
 	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
 	"github.com/milkyhoop/flow-executor/internal/utils"
 	flowpb "github.com/milkyhoop/flow-executor/internal/proto/flow"
 
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrFlowNotFound: file flow tidak ada (di-map ke HTTP 404), beda dengan kegagalan eksekusi
+var ErrFlowNotFound = errors.New("flow not found")
+
+// readFlowFile membaca flow sebagai JSON (.json langsung, .yaml/.yml dikonversi oleh loader).
+// File yang tidak ada dibungkus ErrFlowNotFound.
+func readFlowFile(path string) ([]byte, error) {
+	data, err := loader.ReadFlowJSON(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrFlowNotFound, filepath.Base(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flow file: %w", err)
+	}
+	return data, nil
+}
+
 func RunFlowFromFileWithInput(path string, input map[string]interface{}) error {
-	data, err := os.ReadFile(path)
+	data, err := readFlowFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read flow file: %w", err)
+		return err
 	}
 
 	var flow FlowSpec
@@ -25,33 +46,54 @@ func RunFlowFromFileWithInput(path string, input map[string]interface{}) error {
 		return fmt.Errorf("failed to parse flow JSON: %w", err)
 	}
 
+	if err := injectInput(&flow, input); err != nil {
+		return err
+	}
+	return RunFlow(flow)
+}
+
+// injectInput menggabungkan input caller ke FlowContext dan meng-override tenant_id/user_id
+// context bawaan flow. Kedua bentuk payload didukung, precedence:
+// input["tenant_id"] (top-level) > input["input"]["tenant_id"] (nested) > context flow. Sama untuk user_id.
+// Key yang tidak dideklarasikan di input_schema ditolak (ErrInvalidInput).
+func injectInput(flow *FlowSpec, input map[string]interface{}) error {
+	if err := checkInputKeys(flow.InputSchema, input); err != nil {
+		return err
+	}
 	if flow.Context.Input == nil {
 		flow.Context.Input = make(map[string]interface{})
 	}
-	
 
 	for k, v := range input {
 		flow.Context.Input[k] = v
 	}
 
+	if tenant, ok := inputIdentity(input, "tenant_id"); ok {
+		flow.Context.TenantID = tenant
+	}
+	if user, ok := inputIdentity(input, "user_id"); ok {
+		flow.Context.UserID = user
+	}
+	return nil
+}
 
-	// Check nested input structure
-	if inputMap, ok := input["input"].(map[string]interface{}); ok {
-		if tenant, ok := inputMap["tenant_id"].(string); ok {
-			flow.Context.TenantID = tenant
-		}
-		if user, ok := inputMap["user_id"].(string); ok {
-			flow.Context.UserID = user
+// inputIdentity mencari key string non-kosong di top-level dulu, lalu di nested input["input"]
+func inputIdentity(input map[string]interface{}, key string) (string, bool) {
+	if v, ok := input[key].(string); ok && v != "" {
+		return v, true
+	}
+	if nested, ok := input["input"].(map[string]interface{}); ok {
+		if v, ok := nested[key].(string); ok && v != "" {
+			return v, true
 		}
 	}
-
-	return RunFlow(flow)
+	return "", false
 }
 
 func RunFlowFromFile(path string) error {
-	data, err := os.ReadFile(path)
+	data, err := readFlowFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to read flow file: %w", err)
+		return err
 	}
 
 	var flow FlowSpec
@@ -63,11 +105,20 @@ func RunFlowFromFile(path string) error {
 }
 
 func RunProtobufFlowFromFile(path string) error {
+	return RunProtobufFlowWithInput(path, nil)
+}
+
+// RunProtobufFlowWithInput menjalankan flow .pb dengan context dari proto (jika ada),
+// lalu input caller di-inject seperti RunFlowFromFileWithInput.
+func RunProtobufFlowWithInput(path string, input map[string]interface{}) error {
 	_, file := filepath.Split(path)
 	jsonPath := file[:len(file)-3] + "json"
 	pbPath := path
 
 	err := loader.CompileJSON(jsonPath, pbPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrFlowNotFound, jsonPath)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to compile JSON to .pb: %w", err)
 	}
@@ -86,190 +137,190 @@ func RunProtobufFlowFromFile(path string) error {
 	if err := proto.Unmarshal(data, &protoFlow); err != nil {
 		return fmt.Errorf("failed to unmarshal .pb: %w", err)
 	}
-
-	var nodes []Node
-	for _, pn := range protoFlow.Nodes {
-		nodes = append(nodes, Node{
-			ID:        pn.Id,
-			Hoop:      pn.Hoop,
-			InputFrom: pn.InputFrom,
-		})
+	if err := loader.MigrateFlow(&protoFlow); err != nil {
+		return err
 	}
 
-	flow := FlowSpec{
-		FlowID:    protoFlow.Id,
-		TriggerID: "exec-pb",
-		Context: FlowContext{
-			UserID:   "dummy-user",
-			TenantID: "dummy-tenant",
-		},
-		Nodes: nodes,
+	flow, err := FlowSpecFromProto(&protoFlow)
+	if err != nil {
+		return err
 	}
 
+	if err := injectInput(&flow, input); err != nil {
+		return err
+	}
 	return RunFlow(flow)
 }
 
 func RunFlow(flow FlowSpec) error {
-	utils.Log.Info().Str("flow_id", flow.FlowID).Msg("🚀 Running Flow")
-	if flow.Context.Outputs == nil { flow.Context.Outputs = make(map[string]interface{}) }
-	outputs := make(map[string]map[string]interface{})
-	nodeMap := make(map[string]Node)
-
-	// ✅ PATCH: Inisialisasi Outputs dengan tipe yang benar
-	if flow.Context.Outputs == nil {
-		flow.Context.Outputs = make(map[string]interface{})
-	}
-
-	for _, n := range flow.Nodes {
-		nodeMap[n.ID] = n
-	}
-
-	if len(flow.Nodes) == 0 {
-		return fmt.Errorf("❌ Flow '%s' tidak memiliki node", flow.FlowID)
-	}
-
-	currentID := flow.Nodes[0].ID
-	status := "success"
-
-	for {
-		node, ok := nodeMap[currentID]
-		if !ok {
-			break
-		}
-
-		if node.Hoop == "" {
-			currentID = getNextNodeID(flow.Nodes, node.ID)
-			continue
-		}
-
-		utils.Log.Info().
-			Str("node_id", node.ID).
-			Str("hoop", node.Hoop).
-			Msg("🔧 Executing Node")
-
-		var rawInput map[string]interface{}
-		if node.InputFrom != "" {
-			ref, ok := outputs[node.InputFrom]
-			if !ok {
-				status = "fail"
-				observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-				return fmt.Errorf("node %s: missing input from %s", node.ID, node.InputFrom)
-			}
-			rawInput = ref
-		} else {
-			rawInput = node.Parameters
-		}
-
-		contextMap := flow.ContextToMap()
-		utils.Log.Debug().Interface("context_map", contextMap).Msg("🧵 Context map (sebelum render)")
-		utils.Log.Debug().Interface("context_map", contextMap).Msg("🧩 Merged context + input")
-
-		input := RenderTemplate(rawInput, contextMap)
-		utils.Log.Debug().Interface("rendered_input", input).Msg("🧪 Rendered Input")
-
-		if node.Hoop == "IfNode" {
-			nextID, err := ExecuteIfNode(flow, node, input, outputs)
-			if err != nil {
-				status = "fail"
-				observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-				return err
-			}
-			currentID = nextID
-			continue
-		}
-
-		output, nextID, err := ExecuteNode(flow, node, input)
-		if err != nil {
-			status = "fail"
-			observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-			return err
-		}
-
-		// ✅ PATCH: assignment tanpa panic
-		outputs[node.ID] = output
-		flow.Context.Outputs[node.ID] = output
-
-		event := map[string]interface{}{
-			"flow_id":   flow.FlowID,
-			"node_id":   node.ID,
-			"hoop":      node.Hoop,
-			"input":     input,
-			"output":    output,
-			"user_id":   flow.Context.UserID,
-			"tenant_id": flow.Context.TenantID,
-		}
-		if b, err := json.Marshal(event); err == nil {
-			observer.PublishNotification(flow.Context.UserID, string(b))
-		}
+	return RunFlowContext(context.Background(), flow)
+}
 
-		if nextID != "" {
-			currentID = nextID
-		} else {
-			currentID = getNextNodeID(flow.Nodes, node.ID)
-			if currentID == "" {
-				break
-			}
-		}
+// RunFlowContext menjalankan flow dengan ctx dari caller; ctx diteruskan ke setiap node
+// (dan gRPC call di dalamnya) supaya cancel/deadline dari request ikut berlaku.
+func RunFlowContext(ctx context.Context, flow FlowSpec) error {
+	// FlowSpec di-pass by value tapi map-nya tetap shared, jadi disalin per eksekusi
+	isolateContext(&flow)
+	ctx, release, err := prepareRun(ctx, &flow)
+	if err != nil {
+		return err
 	}
+	defer release()
 
-	observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-	utils.Log.Info().Msg("✅ Flow completed successfully.")
-	return nil
+	_, _, err = run(ctx, flow)
+	return err
 }
 
-
 func RunFlowAndReturnOutput(path string, input map[string]interface{}) (map[string]interface{}, error) {
-	data, err := os.ReadFile(path)
+	return RunFlowAndReturnOutputContext(context.Background(), path, input)
+}
+
+// RunFlowAndReturnOutputContext sama dengan RunFlowAndReturnOutput, dengan ctx dari caller.
+func RunFlowAndReturnOutputContext(ctx context.Context, path string, input map[string]interface{}) (map[string]interface{}, error) {
+	data, err := readFlowFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read flow file: %w", err)
+		return nil, err
 	}
 
-
 	var flow FlowSpec
 	if err := json.Unmarshal(data, &flow); err != nil {
 		return nil, fmt.Errorf("failed to parse flow JSON: %w", err)
 	}
 
-	if flow.Context.Input == nil {
-		flow.Context.Input = make(map[string]interface{})
+	isolateContext(&flow)
+	if err := injectInput(&flow, input); err != nil {
+		return nil, err
 	}
-	for k, v := range input {
-		flow.Context.Input[k] = v
+
+	ctx, release, err := prepareRun(ctx, &flow)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
-	// Check nested input structure
-	if inputMap, ok := input["input"].(map[string]interface{}); ok {
-		if tenant, ok := inputMap["tenant_id"].(string); ok {
-			flow.Context.TenantID = tenant
-		}
-		if user, ok := inputMap["user_id"].(string); ok {
-			flow.Context.UserID = user
+	outputs, lastOutput, err := run(ctx, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := utils.FromContext(ctx)
+	logger.Debug().Interface("outputs", outputs).Msg("🔍 All outputs before final return")
+
+	// Kompatibilitas flow lama: node terakhir kosong → pakai output fetch_answer
+	if len(lastOutput) == 0 {
+		if output, ok := outputs["fetch_answer"]; ok {
+			return output, nil
 		}
 	}
+	logger.Info().Interface("lastOutput", lastOutput).Msg("🐛 Last output before return")
+	return lastOutput, nil
+}
 
+// prepareRun menjalankan semua pengecekan sebelum node pertama (hoop, input_schema, tenant,
+// slot paralel per tenant) lalu memasang trace_id/execution_id + logger ke ctx.
+// release wajib dipanggil caller setelah eksekusi selesai.
+func prepareRun(ctx context.Context, flow *FlowSpec) (context.Context, func(), error) {
+	// Validasi hoop & input_schema di depan, sebelum tenant lookup & node apapun jalan
+	if err := checkHoops(*flow); err != nil {
+		return ctx, nil, err
+	}
+	if err := validateInput(*flow); err != nil {
+		return ctx, nil, err
+	}
+	// Validasi tenant ke TenantManager sebelum eksekusi, sekaligus muat config tenant
+	if err := loadTenant(ctx, flow); err != nil {
+		return ctx, nil, err
+	}
+	// Batas flow paralel per tenant, ditolak (429) alih-alih antre tanpa batas
+	release, err := acquireTenantSlot(flow)
+	if err != nil {
+		return ctx, nil, err
+	}
 
+	if flow.Context.TraceID == "" {
+		flow.Context.TraceID = newTraceID()
+	}
+	// execution_id unik per eksekusi, ikut di semua log node & event Kafka
+	flow.Context.ExecutionID = ExecutionIDFromContext(ctx)
+	if flow.Context.ExecutionID == "" {
+		flow.Context.ExecutionID = NewExecutionID()
+	}
+	logger := utils.Log.With().
+		Str("execution_id", flow.Context.ExecutionID).
+		Str("flow_id", flow.FlowID).
+		Logger()
+	ctx = logger.WithContext(ctx)
+
+	logger.Info().Str("trace_id", flow.Context.TraceID).Msg("🚀 Running Flow")
+	return ctx, release, nil
+}
 
+// run menentukan entry node (start_node / entry detection, bukan posisi array) lalu mengeksekusi flow
+func run(ctx context.Context, flow FlowSpec) (map[string]map[string]interface{}, map[string]interface{}, error) {
+	entryID, err := EntryNode(flow)
+	if err != nil {
+		// Tidak ada node yang jalan, jadi tidak ada durasi; cukup dihitung sebagai eksekusi gagal
+		observer.FlowExecutionCount.WithLabelValues(flow.FlowID, "fail", observer.TenantLabel(flow.Context.TenantID)).Inc()
+		return nil, nil, err
+	}
+	return execute(ctx, flow, entryID, make(map[string]map[string]interface{}), 0)
+}
 
+// execute adalah satu-satunya loop eksekusi node, mulai dari currentID; dipakai run (dari entry
+// node) dan ResumeFlow (dari node setelah checkpoint terakhir). Fitur engine baru (metrics, event,
+// checkpoint, continue_on_error) cukup ditulis di sini.
+// Mengembalikan output semua node dan output node terakhir.
+func execute(ctx context.Context, flow FlowSpec, currentID string, outputs map[string]map[string]interface{}, step int) (map[string]map[string]interface{}, map[string]interface{}, error) {
+	logger := utils.FromContext(ctx)
+
+	// Span flow (anak root span run-flow); tiap node jadi span anak dari span ini
+	ctx, flowSpan := tracing.Start(ctx, "flow "+flow.FlowID, tracing.KindInternal)
+	flowSpan.SetAttr("flow_id", flow.FlowID)
+	flowSpan.SetAttr("execution_id", flow.Context.ExecutionID)
+	flowSpan.SetAttr("tenant_id", flow.Context.TenantID)
+
+	// Durasi end-to-end dicatat sekali di akhir, apapun status akhirnya
+	start := time.Now()
+	status := "success"
+	observer.FlowsInProgress.WithLabelValues(flow.FlowID).Inc()
+	var lastOutput map[string]interface{}
+	defer func() {
+		observer.FlowsInProgress.WithLabelValues(flow.FlowID).Dec()
+		observer.FlowExecutionDuration.WithLabelValues(flow.FlowID, status, observer.TenantLabel(flow.Context.TenantID)).Observe(time.Since(start).Seconds())
+		publishFlowCompleted(ctx, flow, status, time.Since(start), lastOutput)
+		flowSpan.SetAttr("status", status)
+		flowSpan.End()
+	}()
+
+	// Span node aktif; ditutup saat pindah ke node berikutnya atau saat execute selesai
+	var nodeSpan *tracing.Span
+	defer func() { nodeSpan.End() }()
+
+	// nodeError mencatat error node ke metrics + span node
+	nodeError := func(node Node, err error) {
+		recordNodeError(node, err)
+		nodeSpan.RecordError(err)
+	}
 
+	// fail mencatat kegagalan node; error dibungkus PartialError berisi output node yang sudah sukses
+	fail := func(node Node, err error) (map[string]map[string]interface{}, map[string]interface{}, error) {
+		status = "fail"
+		nodeSpan.RecordError(err)
+		flowSpan.RecordError(err)
+		observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status, observer.TenantLabel(flow.Context.TenantID)).Inc()
+		return outputs, nil, newPartialError(node, err, outputs)
+	}
 
-	utils.Log.Info().Str("flow_id", flow.FlowID).Msg("🚀 Running Flow")
-	if flow.Context.Outputs == nil { flow.Context.Outputs = make(map[string]interface{}) }
-	outputs := make(map[string]map[string]interface{})
+	if flow.Context.Outputs == nil {
+		flow.Context.Outputs = make(map[string]interface{})
+	}
 	nodeMap := make(map[string]Node)
 	for _, n := range flow.Nodes {
 		nodeMap[n.ID] = n
 	}
 
-	if len(flow.Nodes) == 0 {
-		return nil, fmt.Errorf("❌ Flow '%s' tidak memiliki node", flow.FlowID)
-	}
-
-	currentID := flow.Nodes[0].ID
-	var lastOutput map[string]interface{}
-	outputs = make(map[string]map[string]interface{})
-	status := "success"
-
 	for {
+		nodeSpan.End()
 		node, ok := nodeMap[currentID]
 		if !ok {
 			break
@@ -280,83 +331,120 @@ func RunFlowAndReturnOutput(path string, input map[string]interface{}) (map[stri
 			continue
 		}
 
-		utils.Log.Info().
+		// ctx di-cancel (client disconnect / deadline) → node berikutnya tidak dijalankan
+		if err := ctx.Err(); err != nil {
+			logger.Warn().Err(err).Str("node_id", node.ID).Msg("🛑 Flow cancelled")
+			return fail(node, err)
+		}
+
+		logger.Info().
 			Str("node_id", node.ID).
 			Str("hoop", node.Hoop).
 			Msg("🔧 Executing Node")
 
-		var rawInput map[string]interface{}
-		if node.InputFrom != "" {
-			ref, ok := outputs[node.InputFrom]
-			if !ok {
-				status = "fail"
-				observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-				return nil, fmt.Errorf("node %s: missing input from %s", node.ID, node.InputFrom)
+		var nodeCtx context.Context
+		nodeCtx, nodeSpan = tracing.Start(ctx, "node "+node.ID, tracing.KindInternal)
+		nodeSpan.SetAttr("node_id", node.ID)
+		nodeSpan.SetAttr("hoop", node.Hoop)
+
+		contextMap := flow.ContextToMap()
+		logger.Debug().Interface("context_map", contextMap).Msg("🧵 Context map (sebelum render)")
+
+		// Output InputFrom + Parameters (Parameters menang), lihat buildNodeInput
+		input, err := buildNodeInput(node, outputs, contextMap)
+		if err != nil {
+			nodeError(node, err)
+			if next, ok := continueAfterError(ctx, flow, node, err, outputs); ok {
+				if currentID = next; currentID == "" {
+					break
+				}
+				continue
 			}
-			rawInput = ref
-		} else {
-			rawInput = node.Parameters
+			return fail(node, err)
 		}
 
-		contextMap := flow.ContextToMap()
-		input := RenderTemplate(rawInput, contextMap)
+		logger.Debug().Interface("rendered_input", input).Msg("🧪 Rendered Input")
+		inputBytes := observePayloadSize(node, "input", input)
 
 		if node.Hoop == "IfNode" {
 			nextID, err := ExecuteIfNode(flow, node, input, outputs)
 			if err != nil {
-				status = "fail"
-				observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-				return nil, err
+				nodeError(node, err)
+				if next, ok := continueAfterError(ctx, flow, node, err, outputs); ok {
+					if currentID = next; currentID == "" {
+						break
+					}
+					continue
+				}
+				return fail(node, err)
 			}
 			currentID = nextID
 			continue
 		}
 
-		output, nextID, err := ExecuteNode(flow, node, input)
+		output, nextID, err := ExecuteNode(nodeCtx, flow, node, input)
 		if err != nil {
-			status = "fail"
-			observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-			return nil, err
+			nodeError(node, err)
+			if next, ok := continueAfterError(ctx, flow, node, err, outputs); ok {
+				if currentID = next; currentID == "" {
+					break
+				}
+				continue
+			}
+			return fail(node, err)
 		}
 
+		// ✅ PATCH: assignment tanpa panic; output nil dinormalisasi jadi map kosong
+		if output == nil {
+			output = map[string]interface{}{}
+		}
 		lastOutput = output
-		outputs[node.ID] = output 
+		outputs[node.ID] = output
 		flow.Context.Outputs[node.ID] = output
+		outputBytes := observePayloadSize(node, "output", output)
 
-
-		if b, err := json.Marshal(map[string]interface{}{
-			"flow_id": flow.FlowID, "node_id": node.ID, "hoop": node.Hoop,
-			"input": input, "output": output,
-			"user_id": flow.Context.UserID, "tenant_id": flow.Context.TenantID,
-		}); err == nil {
-			observer.PublishNotification(flow.Context.UserID, string(b))
+		step++
+		event := map[string]interface{}{
+			"message_id":   eventMessageID(flow, step),
+			"execution_id": flow.Context.ExecutionID,
+			"flow_id":      flow.FlowID,
+			"node_id":      node.ID,
+			"hoop":         node.Hoop,
+			"input":        input,
+			"output":       output,
+			"user_id":      flow.Context.UserID,
+			"tenant_id":    flow.Context.TenantID,
+		}
+		if b := capNodeEvent(ctx, event, inputBytes, outputBytes); b != nil {
+			// Secret hasil render template tidak boleh ikut ke event Kafka
+			observer.PublishNotification(flow.Context.UserID, utils.Redact(string(b)), eventHeaders(flow))
 		}
+		emitNodeEvent(ctx, flow, node, output)
 
 		if nextID != "" {
 			currentID = nextID
 		} else {
 			currentID = getNextNodeID(flow.Nodes, node.ID)
-			if currentID == "" {
-				break
-			}
 		}
-	}
 
-	observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status).Inc()
-	utils.Log.Info().Msg("✅ Flow completed successfully.")
+		// Checkpoint setelah setiap node supaya crash bisa dilanjutkan via ResumeFlow
+		saveCheckpoint(ctx, flow, outputs, currentID, step)
 
-
-	utils.Log.Debug().Interface("outputs", outputs).Msg("🔍 All outputs before final return")
-
-	if len(lastOutput) == 0 {
-		if output, ok := outputs["fetch_answer"]; ok {
-			return output, nil
+		if currentID == "" {
+			break
 		}
 	}
-	utils.Log.Info().Interface("lastOutput", lastOutput).Msg("🐛 Last output before return")
-	return lastOutput, nil
-
 
+	// continue_on_error: flow selesai tapi ada node gagal → "partial", bukan "success"
+	if nodeErrors := TraceFromContext(ctx).Errors(); len(nodeErrors) > 0 {
+		status = "partial"
+		logger.Warn().Int("node_errors", len(nodeErrors)).Msg("⚠️ Flow completed with node errors (continue_on_error).")
+	} else {
+		logger.Info().Msg("✅ Flow completed successfully.")
+	}
+	observer.FlowExecutionCount.WithLabelValues(flow.FlowID, status, observer.TenantLabel(flow.Context.TenantID)).Inc()
+	deleteCheckpoint(ctx, flow)
+	return outputs, lastOutput, nil
 }
 
 func getNextNodeID(nodes []Node, currentID string) string {
@@ -0,0 +1,63 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidEntry: start_node tidak ada atau entry node ambigu (di-map ke HTTP 400)
+var ErrInvalidEntry = errors.New("invalid entry node")
+
+// EntryNode menentukan node pertama dari graph, bukan dari posisi array:
+//   - start_node jika di-set (harus menunjuk node yang ada)
+//   - node satu-satunya yang tidak ditunjuk edge mana pun (true_path/false_path/jump_to/input_from)
+//
+// Flow linear tanpa edge routing sama sekali tetap mulai dari nodes[0] (urutan array = definisinya).
+func EntryNode(flow FlowSpec) (string, error) {
+	if len(flow.Nodes) == 0 {
+		return "", fmt.Errorf("❌ Flow '%s' tidak memiliki node", flow.FlowID)
+	}
+
+	exists := make(map[string]bool, len(flow.Nodes))
+	for _, n := range flow.Nodes {
+		exists[n.ID] = true
+	}
+	if flow.StartNode != "" {
+		if !exists[flow.StartNode] {
+			return "", fmt.Errorf("%w: start_node %s tidak ada di flow '%s'", ErrInvalidEntry, flow.StartNode, flow.FlowID)
+		}
+		return flow.StartNode, nil
+	}
+
+	incoming := make(map[string]bool)
+	routed := false
+	for _, n := range flow.Nodes {
+		for _, target := range []string{n.TruePath, n.FalsePath, n.JumpTo} {
+			if target != "" && target != n.ID {
+				incoming[target] = true
+				routed = true
+			}
+		}
+		if n.InputFrom != "" && n.InputFrom != n.ID {
+			// input_from berarti node ini bergantung pada node lain, jadi bukan entry
+			incoming[n.ID] = true
+		}
+	}
+	if !routed {
+		return flow.Nodes[0].ID, nil
+	}
+
+	var candidates []string
+	for _, n := range flow.Nodes {
+		if !incoming[n.ID] {
+			candidates = append(candidates, n.ID)
+		}
+	}
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 0:
+		return "", fmt.Errorf("%w: flow '%s' tidak punya entry node (semua node ditunjuk edge), set start_node", ErrInvalidEntry, flow.FlowID)
+	}
+	return "", fmt.Errorf("%w: flow '%s' punya beberapa entry node %v, set start_node", ErrInvalidEntry, flow.FlowID, candidates)
+}
@@ -0,0 +1,29 @@
+package executor
+
+import "context"
+
+// NodeEvent dikirim ke EventCallback setiap kali satu node selesai (sukses)
+type NodeEvent struct {
+	ExecutionID string                 `json:"execution_id"`
+	NodeID      string                 `json:"node_id"`
+	Hoop        string                 `json:"hoop"`
+	Output      map[string]interface{} `json:"output"`
+}
+
+// EventCallback dipanggil sinkron dari loop eksekusi, jadi harus cepat (mis. kirim frame WebSocket)
+type EventCallback func(NodeEvent)
+
+type eventCallbackKey struct{}
+
+// WithEventCallback memasang callback per eksekusi ke ctx, di samping event Kafka yang sudah ada
+func WithEventCallback(ctx context.Context, fn EventCallback) context.Context {
+	return context.WithValue(ctx, eventCallbackKey{}, fn)
+}
+
+func emitNodeEvent(ctx context.Context, flow FlowSpec, node Node, output map[string]interface{}) {
+	fn, _ := ctx.Value(eventCallbackKey{}).(EventCallback)
+	if fn == nil {
+		return
+	}
+	fn(NodeEvent{ExecutionID: flow.Context.ExecutionID, NodeID: node.ID, Hoop: node.Hoop, Output: output})
+}
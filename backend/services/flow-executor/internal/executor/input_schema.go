@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidInput: input flow tidak sesuai input_schema (di-map ke HTTP 400)
+var ErrInvalidInput = errors.New("invalid flow input")
+
+// InputSchema adalah subset JSON Schema: field wajib + tipe per field.
+// Jika properties diisi, key input di luar properties/required ditolak kecuali
+// additionalProperties: true.
+type InputSchema struct {
+	Required             []string                    `json:"required,omitempty"`
+	Properties           map[string]InputSchemaField `json:"properties,omitempty"`
+	AdditionalProperties *bool                       `json:"additionalProperties,omitempty"`
+}
+
+type InputSchemaField struct {
+	Type string `json:"type"` // string | number | integer | boolean | object | array
+}
+
+var schemaTypes = map[string]bool{
+	"string": true, "number": true, "integer": true, "boolean": true, "object": true, "array": true,
+}
+
+// validateInput dijalankan sebelum node pertama; field dicari di input (root) lalu
+// user_id/tenant_id/session_id dari context, sama seperti yang dilihat template.
+func validateInput(flow FlowSpec) error {
+	schema := flow.InputSchema
+	if schema == nil {
+		return nil
+	}
+
+	values := make(map[string]interface{}, len(flow.Context.Input)+3)
+	for k, v := range flow.Context.Input {
+		values[k] = v
+	}
+	for k, v := range map[string]string{
+		"user_id":    flow.Context.UserID,
+		"tenant_id":  flow.Context.TenantID,
+		"session_id": flow.Context.SessionID,
+	} {
+		if _, ok := values[k]; !ok && v != "" {
+			values[k] = v
+		}
+	}
+
+	var problems []string
+	for _, field := range schema.Required {
+		if v, ok := values[field]; !ok || v == nil || v == "" {
+			problems = append(problems, fmt.Sprintf("missing required field %q", field))
+		}
+	}
+
+	fields := make([]string, 0, len(schema.Properties))
+	for field := range schema.Properties {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		v, ok := values[field]
+		if !ok || v == nil {
+			continue
+		}
+		want := schema.Properties[field].Type
+		if got := jsonType(v); want != "" && !typeMatches(want, got, v) {
+			problems = append(problems, fmt.Sprintf("field %q must be %s, got %s", field, want, got))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidInput, strings.Join(problems, "; "))
+}
+
+// reservedInputKeys selalu boleh dikirim caller: identitas (dibaca injectInput) dan
+// wrapper nested "input" (bentuk payload lama + tempat EnforceTenant meng-inject tenant_id)
+var reservedInputKeys = map[string]bool{
+	"user_id": true, "tenant_id": true, "session_id": true, "input": true,
+}
+
+// checkInputKeys menolak key input caller yang tidak dideklarasikan di input_schema
+// (root dan nested input["input"]), pengganti DisallowUnknownFields untuk body berbentuk map.
+func checkInputKeys(schema *InputSchema, input map[string]interface{}) error {
+	if schema == nil || len(schema.Properties) == 0 || input == nil {
+		return nil
+	}
+	if schema.AdditionalProperties != nil && *schema.AdditionalProperties {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(schema.Properties)+len(schema.Required))
+	for field := range schema.Properties {
+		allowed[field] = true
+	}
+	for _, field := range schema.Required {
+		allowed[field] = true
+	}
+
+	var unknown []string
+	collect := func(m map[string]interface{}, prefix string) {
+		for k := range m {
+			if !allowed[k] && !reservedInputKeys[k] {
+				unknown = append(unknown, prefix+k)
+			}
+		}
+	}
+	collect(input, "")
+	if nested, ok := input["input"].(map[string]interface{}); ok {
+		collect(nested, "input.")
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("%w: unknown field(s) %s", ErrInvalidInput, strings.Join(unknown, ", "))
+}
+
+// jsonType mengembalikan nama tipe JSON dari value hasil encoding/json
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64, float32, int, int32, int64:
+		return "number"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func typeMatches(want, got string, v interface{}) bool {
+	if want == "integer" {
+		switch n := v.(type) {
+		case float64:
+			return n == float64(int64(n))
+		case int, int32, int64:
+			return true
+		}
+		return false
+	}
+	return want == got
+}
@@ -0,0 +1,72 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/milkyhoop/flow-executor/internal/inventoryclient"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// handleCheckStock memeriksa stok SKU sebelum CreateOrder.
+// Parameters: sku (wajib), quantity (default 1). Stok cukup → true_path,
+// stok kurang → false_path jika ada, selain itu lanjut ke node berikutnya.
+func handleCheckStock(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+
+	sku, ok := input["sku"].(string)
+	if !ok || sku == "" {
+		return nil, "", fmt.Errorf("node %s: invalid or missing sku", node.ID)
+	}
+	quantity, err := quantityParam(input["quantity"])
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: %w", node.ID, err)
+	}
+
+	stock, err := inventoryclient.CheckStock(ctx, sku)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: %w", node.ID, err)
+	}
+
+	inStock := int64(stock.GetAvailable()) >= quantity
+	logger.Info().
+		Str("sku", sku).
+		Int32("available", stock.GetAvailable()).
+		Int64("quantity", quantity).
+		Bool("in_stock", inStock).
+		Msg("📦 Stock checked")
+
+	output := map[string]interface{}{
+		"sku":       sku,
+		"available": stock.GetAvailable(),
+		"reserved":  stock.GetReserved(),
+		"quantity":  quantity,
+		"in_stock":  inStock,
+	}
+	if !inStock && node.FalsePath != "" {
+		return output, node.FalsePath, nil
+	}
+	return output, node.TruePath, nil
+}
+
+// quantityParam: kosong → 1; angka JSON atau string hasil template diterima
+func quantityParam(v interface{}) (int64, error) {
+	switch q := v.(type) {
+	case nil:
+		return 1, nil
+	case string:
+		if q == "" {
+			return 1, nil
+		}
+		n, err := strconv.ParseInt(q, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid quantity %q", q)
+		}
+		return n, nil
+	}
+	if f, ok := toFloat(v); ok {
+		return int64(f), nil
+	}
+	return 0, fmt.Errorf("invalid quantity type %T", v)
+}
@@ -4,329 +4,402 @@ import (
 	"context"
 	"fmt"
 	"time"
-	
+
 	"github.com/milkyhoop/flow-executor/internal/observer"
-	"github.com/milkyhoop/flow-executor/internal/utils"
 	"github.com/milkyhoop/flow-executor/internal/ragclient"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
-func ExecuteNode(flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+// ExecuteNode menjalankan satu node lewat HoopRegistry; hoop yang tidak terdaftar error.
+func ExecuteNode(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
 	start := time.Now()
-	var output map[string]interface{}
-	var nextID string
-
-	switch node.Hoop {
-	case "ShowMenu":
-		var err error
-		output, err = observer.DummyShowMenu(context.Background(), input)
-		if err != nil {
-			return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
-		}
-		nextID = node.TruePath
-
-	case "CreateOrder":
-		var err error
-		output, err = observer.DummyCreateOrder(context.Background(), input)
-		if err != nil {
-			return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
-		}
-		nextID = node.TruePath
 
-	case "SendNotification":
-		var err error
-		output, err = observer.DummySendNotification(context.Background(), input)
-		if err != nil {
-			return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
-		}
-		nextID = node.TruePath
-
-	case "LogComplaint":
-		contextMap := flow.ContextToMap()
-		rendered := RenderTemplate(node.Parameters, contextMap)
-		if rendered["user_id"] == "{{user_id}}" {
-			rendered["user_id"] = contextMap["user_id"]
-		}
-		if rendered["tenant_id"] == "{{tenant_id}}" {
-			rendered["tenant_id"] = contextMap["tenant_id"]
-		}
-
-		node.Input = rendered
-
-		utils.Log.Debug().Interface("rendered", rendered).Msg("🧪 Rendered result")
-
-		userID, ok := rendered["user_id"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid user_id", node.ID)
-		}
-		message, ok := rendered["message"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid message", node.ID)
-		}
+	handler, ok := lookupHoop(node.Hoop)
+	if !ok {
+		logger.Warn().
+			Str("hoop", node.Hoop).
+			Msg("⚠️ Unknown hoop. Skipping...")
+		return nil, "", fmt.Errorf("node %s: unknown hoop %s", node.ID, node.Hoop)
+	}
 
-		complaintID, err := observer.LogComplaint(userID, message)
-		if err != nil {
-			utils.Log.Error().Err(err).Msg("❌ Gagal log complaint")
-			return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
-		}
+	output, nextID, err := handler(ctx, flow, node, input)
+	if err != nil {
+		return nil, "", err
+	}
 
-		utils.Log.Info().Str("complaint_id", complaintID).Msg("✅ Complaint berhasil dikirim")
+	duration := time.Since(start).Seconds()
+	observer.NodeExecutionDuration.WithLabelValues(node.ID, node.Hoop, observer.TenantLabel(flow.Context.TenantID)).Observe(duration)
+	return output, nextID, nil
+}
 
-		rendered["complaint_id"] = complaintID
-		output = rendered
-		nextID = node.TruePath
+// Hoop bawaan; package lain bisa menambah hoop sendiri lewat RegisterHoop
+func init() {
+	RegisterHoop("ShowMenu", handleShowMenu)
+	RegisterHoop("CreateOrder", handleCreateOrder)
+	RegisterHoop("SendNotification", handleSendNotification)
+	RegisterHoop("LogComplaint", handleLogComplaint)
+	RegisterHoop("rag_query", handleRagQuery)
+	RegisterHoop("rag_search_faq", handleRagSearchFAQ)
+	RegisterHoop("rag_llm", handleRagLLM)
+	RegisterHoop("rag_crud_update", handleRagCrudUpdate)
+	RegisterHoop("rag_crud_delete", handleRagCrudDelete)
+	RegisterHoop("rag_crud_update_search", handleRagCrudUpdateSearch)
+	RegisterHoop("rag_crud_create", handleRagCrudCreate)
+	RegisterHoop("SendBotReply", handleSendBotReply)
+	RegisterHoop("StaticReply", handleStaticReply)
+	RegisterHoop("Transform", handleTransform)
+	RegisterHoop("SetVar", handleSetVar)
+	RegisterHoop("Reduce", handleReduce)
+	RegisterHoop("CheckStock", handleCheckStock)
+}
 
+func handleShowMenu(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	output, err := observer.DummyShowMenu(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
+	}
+	return output, node.TruePath, nil
+}
 
-	case "rag_query":
-		contextMap := flow.ContextToMap()
-		rendered := RenderTemplate(node.Parameters, contextMap)
+func handleCreateOrder(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	output, err := observer.DummyCreateOrder(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
+	}
+	return output, node.TruePath, nil
+}
 
-		query, ok := rendered["query"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid or missing query", node.ID)
-		}
-		tenantID, ok := rendered["tenant_id"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
-		}
+func handleSendNotification(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	output, err := observer.DummySendNotification(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
+	}
+	return output, node.TruePath, nil
+}
 
-		utils.Log.Info().
-			Str("query", query).
-			Str("tenant_id", tenantID).
-			Msg("🔍 Menjalankan RAG query")
+func handleLogComplaint(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
-		answer, err := observer.QueryRAG(query, tenantID)
-		if err != nil {
-			return nil, "", fmt.Errorf("node %s: RAG query failed: %w", node.ID, err)
-		}
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
+	if rendered["user_id"] == "{{user_id}}" {
+		rendered["user_id"] = contextMap["user_id"]
+	}
+	if rendered["tenant_id"] == "{{tenant_id}}" {
+		rendered["tenant_id"] = contextMap["tenant_id"]
+	}
 
-		output = map[string]interface{}{
-			"answer": answer,
-		}
-		nextID = node.TruePath
-
-
-	case "rag_search_faq":
-        contextMap := flow.ContextToMap()
-        rendered := RenderTemplate(node.Parameters, contextMap)
-        query, ok := rendered["query"].(string)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing query", node.ID)
-        }
-        tenantID, ok := rendered["tenant_id"].(string)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
-        }
-        utils.Log.Info().
-                Str("query", query).
-                Str("tenant_id", tenantID).
-                Msg("🔍 Searching FAQ database directly")
-                
-        // Use ragclient.QueryRAG yang search database langsung
-        answer, err := ragclient.QueryRAG(query, tenantID)
-        if err != nil {
-                return nil, "", fmt.Errorf("node %s: FAQ search failed: %w", node.ID, err)
-        }
-        output = map[string]interface{}{
-                "answer": answer,
-        }
-        nextID = node.TruePath
-
-
-
-		
-	case "rag_llm":
-		contextMap := flow.ContextToMap()
-		rendered := RenderTemplate(node.Parameters, contextMap)
+	node.Input = rendered
 
-		query, ok := rendered["query"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid or missing query", node.ID)
-		}
-		tenantID, ok := rendered["tenant_id"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
-		}
+	logger.Debug().Interface("rendered", rendered).Msg("🧪 Rendered result")
 
-		utils.Log.Info().
-			Str("query", query).
-			Str("tenant_id", tenantID).
-			Msg("🧠 Menjalankan RAG LLM")
+	userID, ok := rendered["user_id"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid user_id", node.ID)
+	}
+	message, ok := rendered["message"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid message", node.ID)
+	}
 
-		answer, err := observer.QueryRAGLLM(query, tenantID)
-		if err != nil {
-			return nil, "", fmt.Errorf("node %s: RAG LLM failed: %w", node.ID, err)
-		}
+	// product/source/emotion opsional; kosong → default di ComplaintMeta.WithDefaults
+	meta := observer.ComplaintMeta{}
+	meta.Product, _ = rendered["product"].(string)
+	meta.Source, _ = rendered["source"].(string)
+	meta.Emotion, _ = rendered["emotion"].(string)
 
-		output = map[string]interface{}{
-			"answer": answer,
-		}
-		nextID = node.TruePath
+	complaintID, err := complaintLogger(userID, message, meta.WithDefaults())
+	if err != nil {
+		logger.Error().Err(err).Msg("❌ Gagal log complaint")
+		return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
+	}
 
+	logger.Info().Str("complaint_id", complaintID).Msg("✅ Complaint berhasil dikirim")
 
+	rendered["complaint_id"] = complaintID
+	output = rendered
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
+func handleRagQuery(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
-	case "rag_crud_update":
-        contextMap := flow.ContextToMap()
-        rendered := RenderTemplate(node.Parameters, contextMap)
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
 
-        id, ok := rendered["id"].(float64) // JSON numbers come as float64
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing id", node.ID)
-        }
-        title, ok := rendered["title"].(string)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing title", node.ID)
-        }
-        content, ok := rendered["content"].(string)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing content", node.ID)
-        }
+	query, ok := rendered["query"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing query", node.ID)
+	}
+	tenantID, ok := rendered["tenant_id"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
+	}
 
-        utils.Log.Info().
-                Int32("id", int32(id)).
-                Str("title", title).
-                Msg("🔄 Menjalankan RAG CRUD update")
+	logger.Info().
+		Str("query", query).
+		Str("tenant_id", tenantID).
+		Msg("🔍 Menjalankan RAG query")
 
-        result, err := ragclient.UpdateRAGDocument(int32(id), title, content)
-        if err != nil {
-                return nil, "", fmt.Errorf("node %s: RAG CRUD update failed: %w", node.ID, err)
-        }
+	answer, err := observer.QueryRAG(ctx, query, tenantID)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: RAG query failed: %w", node.ID, err)
+	}
 
-        output = map[string]interface{}{
-                "result": result,
-        }
-        nextID = node.TruePath
+	output, err = ragAnswerOutput(flow, node, rendered, answer)
+	if err != nil {
+		return nil, "", err
+	}
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
+func handleRagSearchFAQ(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
+	query, ok := rendered["query"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing query", node.ID)
+	}
+	tenantID, ok := rendered["tenant_id"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
+	}
+	logger.Info().
+		Str("query", query).
+		Str("tenant_id", tenantID).
+		Msg("🔍 Searching FAQ database directly")
+
+	// Search database langsung; similarity/top_k: node > tenant > global (lihat rag_options.go)
+	answer, err := ragclient.QueryRAGWithOptions(ctx, query, tenantID, ragSearchOptions(flow, rendered))
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: FAQ search failed: %w", node.ID, err)
+	}
+	output, err = ragAnswerOutput(flow, node, rendered, answer)
+	if err != nil {
+		return nil, "", err
+	}
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
-	case "rag_crud_delete":
-        contextMap := flow.ContextToMap()
-        rendered := RenderTemplate(node.Parameters, contextMap)
+func handleRagLLM(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
-        id, ok := rendered["id"].(float64)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing id", node.ID)
-        }
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
 
-        utils.Log.Info().
-                Int32("id", int32(id)).
-                Msg("🗑️ Menjalankan RAG CRUD delete")
+	query, ok := rendered["query"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing query", node.ID)
+	}
+	tenantID, ok := rendered["tenant_id"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
+	}
 
-        result, err := ragclient.DeleteRAGDocument(int32(id))
-        if err != nil {
-                return nil, "", fmt.Errorf("node %s: RAG CRUD delete failed: %w", node.ID, err)
-        }
+	logger.Info().
+		Str("query", query).
+		Str("tenant_id", tenantID).
+		Msg("🧠 Menjalankan RAG LLM")
 
-        output = map[string]interface{}{
-                "result": result,
-        }
-        nextID = node.TruePath
+	answer, err := observer.QueryRAGLLM(ctx, query, tenantID)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: RAG LLM failed: %w", node.ID, err)
+	}
 
+	output, err = ragAnswerOutput(flow, node, rendered, answer)
+	if err != nil {
+		return nil, "", err
+	}
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
-	case "rag_crud_update_search":
-        contextMap := flow.ContextToMap()
-        rendered := RenderTemplate(node.Parameters, contextMap)
+func handleRagCrudUpdate(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
-        tenantID, ok := rendered["tenant_id"].(string)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
-        }
-        searchContent, ok := rendered["search_content"].(string)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing search_content", node.ID)
-        }
-        newContent, ok := rendered["new_content"].(string)
-        if !ok {
-                return nil, "", fmt.Errorf("node %s: invalid or missing new_content", node.ID)
-        }
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
 
-        utils.Log.Info().
-                Str("tenant_id", tenantID).
-                Str("search_content", searchContent).
-                Msg("🔍 Menjalankan RAG CRUD update by search")
+	id, ok := rendered["id"].(float64) // JSON numbers come as float64
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing id", node.ID)
+	}
+	title, ok := rendered["title"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing title", node.ID)
+	}
+	content, ok := rendered["content"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing content", node.ID)
+	}
 
-        result, err := ragclient.UpdateRAGDocumentBySearch(tenantID, searchContent, newContent)
-        if err != nil {
-                return nil, "", fmt.Errorf("node %s: RAG CRUD update by search failed: %w", node.ID, err)
-        }
+	logger.Info().
+		Int32("id", int32(id)).
+		Str("title", title).
+		Msg("🔄 Menjalankan RAG CRUD update")
 
-        output = map[string]interface{}{
-                "result": result,
-        }
-        nextID = node.TruePath
+	result, err := ragclient.UpdateRAGDocument(ctx, int32(id), title, content)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: RAG CRUD update failed: %w", node.ID, err)
+	}
 
+	output = map[string]interface{}{
+		"result": result,
+	}
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
+func handleRagCrudDelete(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
-	case "rag_crud_create":
-		contextMap := flow.ContextToMap()
-		rendered := RenderTemplate(node.Parameters, contextMap)
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
 
-		tenantID, ok := rendered["tenant_id"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
-		}
-		title, ok := rendered["title"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid or missing title", node.ID)
-		}
-		content, ok := rendered["content"].(string)
-		if !ok {
-			return nil, "", fmt.Errorf("node %s: invalid or missing content", node.ID)
-		}
+	id, ok := rendered["id"].(float64)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing id", node.ID)
+	}
 
-		utils.Log.Info().
-			Str("tenant_id", tenantID).
-			Str("title", title).
-			Msg("📝 Menjalankan RAG CRUD create")
+	logger.Info().
+		Int32("id", int32(id)).
+		Msg("🗑️ Menjalankan RAG CRUD delete")
 
-		result, err := ragclient.CreateRAGDocument(tenantID, title, content)
-		if err != nil {
-			return nil, "", fmt.Errorf("node %s: RAG CRUD create failed: %w", node.ID, err)
-		}
+	result, err := ragclient.DeleteRAGDocument(ctx, int32(id))
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: RAG CRUD delete failed: %w", node.ID, err)
+	}
 
-		output = map[string]interface{}{
-			"result": result,
-		}
-		nextID = node.TruePath
+	output = map[string]interface{}{
+		"result": result,
+	}
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
+func handleRagCrudUpdateSearch(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
 
+	tenantID, ok := rendered["tenant_id"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
+	}
+	searchContent, ok := rendered["search_content"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing search_content", node.ID)
+	}
+	newContent, ok := rendered["new_content"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing new_content", node.ID)
+	}
 
+	logger.Info().
+		Str("tenant_id", tenantID).
+		Str("search_content", searchContent).
+		Msg("🔍 Menjalankan RAG CRUD update by search")
 
+	result, err := ragclient.UpdateRAGDocumentBySearch(ctx, tenantID, searchContent, newContent)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: RAG CRUD update by search failed: %w", node.ID, err)
+	}
 
+	output = map[string]interface{}{
+		"result": result,
+	}
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
+func handleRagCrudCreate(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	logger := utils.FromContext(ctx)
+	var output map[string]interface{}
+	var nextID string
 
+	contextMap := flow.ContextToMap()
+	rendered := RenderTemplate(node.Parameters, contextMap)
 
+	tenantID, ok := rendered["tenant_id"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing tenant_id", node.ID)
+	}
+	title, ok := rendered["title"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing title", node.ID)
+	}
+	content, ok := rendered["content"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing content", node.ID)
+	}
 
+	// source & tags opsional, default di ragclient (conversational_faq / [faq])
+	source, _ := rendered["source"].(string)
+	tags, err := stringSliceParam(rendered["tags"])
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: invalid tags: %w", node.ID, err)
+	}
 
+	logger.Info().
+		Str("tenant_id", tenantID).
+		Str("title", title).
+		Strs("tags", tags).
+		Msg("📝 Menjalankan RAG CRUD create")
 
+	result, err := ragclient.CreateRAGDocument(ctx, tenantID, title, content, source, tags)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: RAG CRUD create failed: %w", node.ID, err)
+	}
 
-	
-	
-	
-	
-	
-	
-	case "SendBotReply":
-		var err error
-		output, err = observer.HandleSendBotReply(context.Background(), input)
-		if err != nil {
-			return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
-		}
-		nextID = node.TruePath
+	output = map[string]interface{}{
+		"result": result,
+	}
+	nextID = node.TruePath
+	return output, nextID, nil
+}
 
-	default:
-		utils.Log.Warn().
-			Str("hoop", node.Hoop).
-			Msg("⚠️ Unknown hoop. Skipping...")
-		return nil, "", fmt.Errorf("node %s: unknown hoop %s", node.ID, node.Hoop)
+func handleSendBotReply(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	output, err := observer.HandleSendBotReply(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s failed: %w", node.ID, err)
 	}
+	return output, node.TruePath, nil
+}
 
-	duration := time.Since(start).Seconds()
-	observer.NodeExecutionDuration.WithLabelValues(node.ID, node.Hoop).Observe(duration)
-	return output, nextID, nil
+// handleStaticReply: node terminal paling sederhana untuk chatbot, parameters.message
+// (sudah dirender terhadap context oleh engine) dikembalikan sebagai {"message": ...}
+func handleStaticReply(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	message, ok := input["message"].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: invalid or missing message", node.ID)
+	}
+	return map[string]interface{}{"message": message}, node.TruePath, nil
 }
 
+// ExecuteIfNode membandingkan field dengan value lalu memilih TruePath/FalsePath.
+// Dengan input_from: field adalah key di output node tersebut (perilaku lama).
+// Tanpa input_from: field adalah template yang dirender terhadap context penuh, mis. "{{input.premium}}".
 func ExecuteIfNode(flow FlowSpec, node Node, input map[string]interface{}, outputs map[string]map[string]interface{}) (string, error) {
 	field, ok := input["field"].(string)
 	if !ok {
@@ -341,18 +414,36 @@ func ExecuteIfNode(flow FlowSpec, node Node, input map[string]interface{}, outpu
 		return "", fmt.Errorf("IfNode %s: missing value", node.ID)
 	}
 
-	refOutput, ok := outputs[node.InputFrom]
-	if !ok {
-		return "", fmt.Errorf("IfNode %s: missing input from node %s", node.ID, node.InputFrom)
-	}
-	compareVal, exists := refOutput[field]
-	if !exists {
-		return "", fmt.Errorf("IfNode %s: field %s not found in input from node %s", node.ID, field, node.InputFrom)
+	var compareVal interface{}
+	if node.InputFrom == "" {
+		// Template di-resolve dari parameter mentah supaya tipe asli (bool/angka) tidak jadi string
+		contextMap := flow.ContextToMap()
+		compareVal = resolveIfOperand(node.Parameters["field"], contextMap)
+		value = resolveIfOperand(node.Parameters["value"], contextMap)
+	} else {
+		refOutput, ok := outputs[node.InputFrom]
+		if !ok {
+			return "", fmt.Errorf("IfNode %s: missing input from node %s", node.ID, node.InputFrom)
+		}
+		// Bedakan node yang tidak menghasilkan output sama sekali dengan field yang tidak ada
+		if len(refOutput) == 0 {
+			return "", fmt.Errorf("IfNode %s: node %s produced no output", node.ID, node.InputFrom)
+		}
+		var exists bool
+		compareVal, exists = refOutput[field]
+		// Field yang tidak ada dianggap null, jadi "== null" tetap bisa dipakai
+		if !exists && !(operator == "==" && isNullValue(value)) {
+			return "", fmt.Errorf("IfNode %s: field %s not found in input from node %s", node.ID, field, node.InputFrom)
+		}
 	}
 
 	switch operator {
 	case "==":
-		if compareVal == value {
+		equal, err := ifEquals(compareVal, value)
+		if err != nil {
+			return "", fmt.Errorf("IfNode %s: field %s: %w", node.ID, field, err)
+		}
+		if equal {
 			return node.TruePath, nil
 		}
 		return node.FalsePath, nil
@@ -373,3 +464,11 @@ func ExecuteIfNode(flow FlowSpec, node Node, input map[string]interface{}, outpu
 		return node.FalsePath, nil
 	}
 }
+
+// resolveIfOperand: string template di-resolve terhadap context (placeholder tunggal tetap bertipe asli)
+func resolveIfOperand(raw interface{}, contextMap map[string]interface{}) interface{} {
+	if tmpl, ok := raw.(string); ok {
+		return resolveTransformValue(tmpl, contextMap, true)
+	}
+	return raw
+}
@@ -0,0 +1,45 @@
+package executor
+
+import (
+	"context"
+	"errors"
+)
+
+// PartialError membungkus error node dengan output node-node yang sudah sukses sebelumnya.
+// Unwrap mengembalikan error asli, jadi errors.Is/As (ErrInvalidInput, gRPC status, dll) tetap jalan.
+type PartialError struct {
+	Err        error
+	FailedNode string
+	Outputs    map[string]map[string]interface{}
+}
+
+func (e *PartialError) Error() string { return e.Err.Error() }
+
+func (e *PartialError) Unwrap() error { return e.Err }
+
+func newPartialError(node Node, err error, outputs map[string]map[string]interface{}) error {
+	snapshot := make(map[string]map[string]interface{}, len(outputs))
+	for id, out := range outputs {
+		snapshot[id] = out
+	}
+	return &PartialError{Err: err, FailedNode: node.ID, Outputs: snapshot}
+}
+
+// PartialOutputs mengambil output node yang sudah sukses dari error eksekusi (nil jika tidak ada)
+func PartialOutputs(err error) map[string]map[string]interface{} {
+	var pe *PartialError
+	if errors.As(err, &pe) {
+		return pe.Outputs
+	}
+	return nil
+}
+
+// RunFlowAndReturnPartial sama dengan RunFlowAndReturnOutputContext, tetapi saat gagal di tengah
+// juga mengembalikan output node yang sudah sukses (mis. order sudah dibuat, notifikasi gagal).
+func RunFlowAndReturnPartial(ctx context.Context, path string, input map[string]interface{}) (map[string]interface{}, map[string]map[string]interface{}, error) {
+	output, err := RunFlowAndReturnOutputContext(ctx, path, input)
+	if err != nil {
+		return nil, PartialOutputs(err), err
+	}
+	return output, nil, nil
+}
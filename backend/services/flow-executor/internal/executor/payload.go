@@ -0,0 +1,58 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// observePayloadSize mencatat ukuran JSON payload node ke node_payload_bytes{node_id, direction};
+// direction "input" (setelah render template) atau "output". Mengembalikan ukuran dalam byte.
+func observePayloadSize(node Node, direction string, payload map[string]interface{}) int {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return 0
+	}
+	observer.NodePayloadBytes.WithLabelValues(node.ID, direction).Observe(float64(len(b)))
+	return len(b)
+}
+
+// nodeEventMaxBytes dari ENV NODE_EVENT_MAX_BYTES (default 1MB = batas default message Kafka, 0 = tanpa batas)
+func nodeEventMaxBytes() int {
+	if v := os.Getenv("NODE_EVENT_MAX_BYTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return 1 << 20
+}
+
+// capNodeEvent: event node yang melebihi NODE_EVENT_MAX_BYTES dikirim tanpa input/output
+// (diganti ukuran aslinya) supaya tidak ditolak broker Kafka. Hasil flow sendiri tidak berubah.
+func capNodeEvent(ctx context.Context, event map[string]interface{}, inputBytes, outputBytes int) []byte {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return nil
+	}
+	limit := nodeEventMaxBytes()
+	if limit == 0 || len(b) <= limit {
+		return b
+	}
+
+	utils.FromContext(ctx).Warn().
+		Interface("node_id", event["node_id"]).
+		Int("event_bytes", len(b)).
+		Int("max_bytes", limit).
+		Msg("✂️ Event node terlalu besar, input/output di-drop dari event Kafka")
+	event["input"] = map[string]interface{}{"truncated": true, "bytes": inputBytes}
+	event["output"] = map[string]interface{}{"truncated": true, "bytes": outputBytes}
+	b, err = json.Marshal(event)
+	if err != nil {
+		return nil
+	}
+	return b
+}
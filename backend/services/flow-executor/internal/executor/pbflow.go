@@ -0,0 +1,46 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	flowpb "github.com/milkyhoop/flow-executor/internal/proto/flow"
+)
+
+// FlowSpecFromProto mengubah flow hasil compile (.pb) menjadi FlowSpec yang bisa dijalankan,
+// termasuk parameters, branching (true/false/jump) dan context, setara flow JSON.
+func FlowSpecFromProto(pf *flowpb.Flow) (FlowSpec, error) {
+	flow := FlowSpec{
+		FlowID:    pf.GetId(),
+		TriggerID: "exec-pb",
+	}
+
+	for _, pn := range pf.GetNodes() {
+		node := Node{
+			ID:        pn.GetId(),
+			Hoop:      pn.GetHoop(),
+			InputFrom: pn.GetInputFrom(),
+			TruePath:  pn.GetTruePath(),
+			FalsePath: pn.GetFalsePath(),
+			JumpTo:    pn.GetJumpTo(),
+		}
+		if raw := pn.GetParametersJson(); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &node.Parameters); err != nil {
+				return FlowSpec{}, fmt.Errorf("node %s: invalid parameters_json: %w", pn.GetId(), err)
+			}
+		}
+		flow.Nodes = append(flow.Nodes, node)
+	}
+
+	if pc := pf.GetContext(); pc != nil {
+		flow.Context.UserID = pc.GetUserId()
+		flow.Context.TenantID = pc.GetTenantId()
+		flow.Context.SessionID = pc.GetSessionId()
+		if raw := pc.GetInputJson(); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &flow.Context.Input); err != nil {
+				return FlowSpec{}, fmt.Errorf("invalid context input_json: %w", err)
+			}
+		}
+	}
+	return flow, nil
+}
@@ -0,0 +1,53 @@
+package executor
+
+import (
+	"strconv"
+
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+)
+
+// Tuning RAG di-resolve tiga level, yang pertama terisi menang:
+//
+//  1. parameter node:   similarity_threshold, top_k, max_content_length
+//  2. default tenant:   TenantConfig.RagSimilarityThreshold / RagTopK / RagMaxContentLength
+//     (ENV TENANT_<ALIAS>_RAG_SIMILARITY_THRESHOLD / _RAG_TOP_K / _RAG_MAX_CONTENT_LENGTH)
+//  3. default global:   RAG_SIMILARITY_THRESHOLD (0.7), RAG_TOP_K (1), RAG_MAX_CONTENT_LENGTH (4000)
+//
+// Nilai nol / kosong berarti "tidak di-set" dan jatuh ke level berikutnya.
+
+// ragSearchOptions menggabungkan parameter node (sudah dirender) dengan default tenant;
+// field yang masih nol diisi default global oleh ragclient.
+func ragSearchOptions(flow FlowSpec, params map[string]interface{}) ragclient.SearchOptions {
+	var opts ragclient.SearchOptions
+	if t := flow.Context.Tenant; t != nil {
+		opts.SimilarityThreshold = float32(t.RagSimilarityThreshold)
+		opts.TopK = t.RagTopK
+	}
+	if v, ok := numberParam(params["similarity_threshold"]); ok && v > 0 {
+		opts.SimilarityThreshold = float32(v)
+	}
+	if v, ok := numberParam(params["top_k"]); ok && v > 0 {
+		opts.TopK = int(v)
+	}
+	return opts
+}
+
+// ragContentLimit: max_content_length node > tenant > RAG_MAX_CONTENT_LENGTH (0 = tanpa batas)
+func ragContentLimit(flow FlowSpec, params map[string]interface{}) int {
+	if v, ok := numberParam(params["max_content_length"]); ok && v > 0 {
+		return int(v)
+	}
+	if t := flow.Context.Tenant; t != nil && t.RagMaxContentLength > 0 {
+		return t.RagMaxContentLength
+	}
+	return ragMaxContentLength()
+}
+
+// numberParam menerima angka JSON atau string hasil render template ("0.8", "{{top_k}}")
+func numberParam(v interface{}) (float64, bool) {
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+	return toFloat(v)
+}
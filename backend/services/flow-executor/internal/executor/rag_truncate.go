@@ -0,0 +1,68 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ErrRAGContentTooLarge: jawaban RAG melebihi RAG_MAX_CONTENT_LENGTH dan node di-set "truncate": false
+var ErrRAGContentTooLarge = errors.New("RAG content too large")
+
+// ragTruncateMarker ditambahkan di akhir jawaban yang dipotong
+const ragTruncateMarker = "…"
+
+// ragMaxContentLength dari ENV RAG_MAX_CONTENT_LENGTH (karakter, default 4000, 0 = tanpa batas);
+// default global, bisa di-override tenant & node (lihat ragContentLimit)
+func ragMaxContentLength() int {
+	if v := os.Getenv("RAG_MAX_CONTENT_LENGTH"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return 4000
+}
+
+// limitRagContent memotong jawaban RAG yang terlalu panjang sebelum masuk template & event Kafka.
+// Default dipotong + marker; dengan parameter node "truncate": false justru error.
+// Mengembalikan true jika jawaban dipotong.
+func limitRagContent(flow FlowSpec, node Node, params map[string]interface{}, answer string) (string, bool, error) {
+	limit := ragContentLimit(flow, params)
+	runes := []rune(answer)
+	if limit == 0 || len(runes) <= limit {
+		return answer, false, nil
+	}
+	if !ragTruncateEnabled(node) {
+		return "", false, fmt.Errorf("%w: node %s mengembalikan %d karakter (maks %d)", ErrRAGContentTooLarge, node.ID, len(runes), limit)
+	}
+	return string(runes[:limit]) + ragTruncateMarker, true, nil
+}
+
+// ragTruncateEnabled: parameter "truncate" (bool atau string) default true
+func ragTruncateEnabled(node Node) bool {
+	switch v := node.Parameters["truncate"].(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return true
+}
+
+// ragAnswerOutput membentuk output node RAG {"answer": ...} setelah dibatasi panjangnya
+func ragAnswerOutput(flow FlowSpec, node Node, params map[string]interface{}, answer string) (map[string]interface{}, error) {
+	answer, truncated, err := limitRagContent(flow, node, params, answer)
+	if err != nil {
+		return nil, err
+	}
+	output := map[string]interface{}{
+		"answer": answer,
+	}
+	if truncated {
+		output["truncated"] = true
+	}
+	return output, nil
+}
@@ -0,0 +1,131 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// reduceOps adalah operasi yang didukung hoop Reduce
+var reduceOps = map[string]bool{"sum": true, "count": true, "concat": true, "min": true, "max": true}
+
+// handleReduce mengagregasi array menjadi satu nilai di output["result"].
+// Parameters:
+//   - items: template ke array, mis. "{{loop_id.results}}"
+//   - operation: sum | count | concat | min | max
+//   - field: key (boleh nested, "a.b") di tiap item; kosong = item itu sendiri
+//   - separator: pemisah untuk concat (default "")
+func handleReduce(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	op, _ := input["operation"].(string)
+	if !reduceOps[op] {
+		return nil, "", fmt.Errorf("node %s: operation %q tidak didukung (sum, count, concat, min, max)", node.ID, op)
+	}
+
+	// items diambil dari Parameters mentah: input sudah dirender jadi string oleh engine
+	rawItems, trusted := node.Parameters["items"]
+	if !trusted {
+		rawItems = input["items"]
+	}
+	items, err := reduceItems(flow, rawItems, trusted)
+	if err != nil {
+		return nil, "", fmt.Errorf("node %s: %w", node.ID, err)
+	}
+
+	field, _ := input["field"].(string)
+	values := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		if field == "" {
+			values = append(values, item)
+			continue
+		}
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, "", fmt.Errorf("node %s: items[%d] bukan object, tidak punya field %s", node.ID, i, field)
+		}
+		val, ok := getNestedValue(obj, field)
+		if !ok {
+			return nil, "", fmt.Errorf("node %s: items[%d] tidak punya field %s", node.ID, i, field)
+		}
+		values = append(values, val)
+	}
+
+	var result interface{}
+	switch op {
+	case "count":
+		result = len(values)
+	case "concat":
+		sep, _ := input["separator"].(string)
+		parts := make([]string, len(values))
+		for i, v := range values {
+			parts[i] = fmt.Sprintf("%v", v)
+		}
+		result = strings.Join(parts, sep)
+	default:
+		nums := make([]float64, len(values))
+		for i, v := range values {
+			n, ok := toFloat(v)
+			if !ok {
+				return nil, "", fmt.Errorf("node %s: items[%d] bukan angka untuk %s: %v", node.ID, i, op, v)
+			}
+			nums[i] = n
+		}
+		if len(nums) == 0 && op != "sum" {
+			return nil, "", fmt.Errorf("node %s: %s butuh minimal satu item", node.ID, op)
+		}
+		result = reduceNumbers(op, nums)
+	}
+
+	return map[string]interface{}{"result": result, "count": len(values)}, node.TruePath, nil
+}
+
+// reduceItems me-resolve parameter items menjadi array; string diperlakukan sebagai template
+func reduceItems(flow FlowSpec, raw interface{}, trusted bool) ([]interface{}, error) {
+	if tmpl, ok := raw.(string); ok {
+		raw = resolveTransformValue(tmpl, flow.ContextToMap(), trusted)
+	}
+	switch items := raw.(type) {
+	case []interface{}:
+		return items, nil
+	case []map[string]interface{}:
+		out := make([]interface{}, len(items))
+		for i, item := range items {
+			out[i] = item
+		}
+		return out, nil
+	case nil:
+		return nil, fmt.Errorf("parameter items wajib diisi")
+	default:
+		return nil, fmt.Errorf("items harus array, dapat %T", raw)
+	}
+}
+
+func reduceNumbers(op string, nums []float64) float64 {
+	var acc float64
+	for i, n := range nums {
+		switch {
+		case op == "sum":
+			acc += n
+		case i == 0:
+			acc = n
+		case op == "min" && n < acc, op == "max" && n > acc:
+			acc = n
+		}
+	}
+	return acc
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownHoop: flow memakai hoop yang tidak terdaftar (di-map ke HTTP 400)
+var ErrUnknownHoop = errors.New("unknown hoop")
+
+// HoopHandler mengeksekusi satu node dan mengembalikan output serta ID node berikutnya
+// (kosong = lanjut ke node setelahnya).
+type HoopHandler func(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error)
+
+// hoopRegistry memetakan nama hoop ke handler-nya
+var (
+	hoopRegistryMu sync.RWMutex
+	hoopRegistry   = map[string]HoopHandler{}
+)
+
+// RegisterHoop mendaftarkan (atau mengganti) handler untuk nama hoop.
+// Dipanggil dari init() supaya hoop sudah terdaftar sebelum flow pertama jalan.
+func RegisterHoop(name string, handler HoopHandler) {
+	if name == "" || handler == nil {
+		panic("executor: RegisterHoop butuh nama dan handler")
+	}
+	hoopRegistryMu.Lock()
+	defer hoopRegistryMu.Unlock()
+	hoopRegistry[name] = handler
+}
+
+func lookupHoop(name string) (HoopHandler, bool) {
+	hoopRegistryMu.RLock()
+	defer hoopRegistryMu.RUnlock()
+	handler, ok := hoopRegistry[name]
+	return handler, ok
+}
+
+// RegisteredHoops mengembalikan nama hoop terdaftar, terurut
+func RegisteredHoops() []string {
+	hoopRegistryMu.RLock()
+	defer hoopRegistryMu.RUnlock()
+	names := make([]string, 0, len(hoopRegistry))
+	for name := range hoopRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isKnownHoop: hoop terdaftar, IfNode (ditangani engine), atau kosong (node dilewati)
+func isKnownHoop(name string) bool {
+	if name == "" || name == "IfNode" {
+		return true
+	}
+	_, ok := lookupHoop(name)
+	return ok
+}
+
+// checkHoops menolak flow dengan hoop tak terdaftar sebelum node pertama jalan,
+// supaya tidak gagal di tengah flow setelah node lain sudah punya efek samping.
+func checkHoops(flow FlowSpec) error {
+	seen := map[string]bool{}
+	var unknown []string
+	for _, n := range flow.Nodes {
+		if !isKnownHoop(n.Hoop) && !seen[n.Hoop] {
+			seen[n.Hoop] = true
+			unknown = append(unknown, n.Hoop)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return fmt.Errorf("%w in flow %s: %s", ErrUnknownHoop, flow.FlowID, strings.Join(unknown, ", "))
+}
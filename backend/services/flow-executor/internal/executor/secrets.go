@@ -0,0 +1,42 @@
+package executor
+
+import (
+	"os"
+	"strings"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// SecretsProvider me-resolve {{secret.NAME}} di template; implementasi lain (mis. Vault)
+// bisa dipasang lewat SetSecretsProvider.
+type SecretsProvider interface {
+	GetSecret(name string) (string, bool)
+}
+
+// EnvSecretsProvider membaca secret dari ENV SECRET_<NAME> (default)
+type EnvSecretsProvider struct{}
+
+func (EnvSecretsProvider) GetSecret(name string) (string, bool) {
+	return os.LookupEnv("SECRET_" + strings.ToUpper(name))
+}
+
+var secretsProvider SecretsProvider = EnvSecretsProvider{}
+
+// SetSecretsProvider mengganti sumber secret; nil kembali ke EnvSecretsProvider
+func SetSecretsProvider(p SecretsProvider) {
+	if p == nil {
+		p = EnvSecretsProvider{}
+	}
+	secretsProvider = p
+}
+
+// resolveSecret mengambil secret dan mendaftarkannya ke redactor log/event
+func resolveSecret(name string) (string, bool) {
+	value, ok := secretsProvider.GetSecret(name)
+	if !ok {
+		utils.Log.Warn().Str("secret", name).Msg("⚠️ Secret tidak ditemukan, placeholder dibiarkan")
+		return "", false
+	}
+	utils.RegisterSecret(value)
+	return value, true
+}
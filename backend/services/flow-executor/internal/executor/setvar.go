@@ -0,0 +1,21 @@
+package executor
+
+import "context"
+
+// handleSetVar menulis setiap parameter ke flow.Context.Vars sehingga node berikutnya
+// bisa memakai {{nama}} atau {{vars.nama}}. Template tunggal mempertahankan tipe aslinya
+// (sama seperti Transform). Output node berisi vars yang baru di-set.
+func handleSetVar(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	contextMap := flow.ContextToMap()
+	output := make(map[string]interface{}, len(node.Parameters))
+	for name, spec := range node.Parameters {
+		value := spec
+		if tmpl, ok := spec.(string); ok {
+			value = resolveTransformValue(tmpl, contextMap, true)
+		}
+		// Vars sudah di-isolate per eksekusi (isolateContext), aman ditulis langsung
+		flow.Context.Vars[name] = value
+		output[name] = value
+	}
+	return output, node.TruePath, nil
+}
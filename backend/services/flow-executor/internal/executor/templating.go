@@ -2,59 +2,116 @@ package executor
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
+// templatePattern: {{ path }} atau {{ path | default "fallback" }}
+var templatePattern = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_\.]+)\s*(\|\s*default\s+"([^"]*)"\s*)?\}\}`)
+
 // RenderTemplate mengganti placeholder seperti {{input.message}} menjadi value dari input map.
 // Bisa menangani nested key seperti input.message → dicari di data["input"]["message"].
+// {{secret.NAME}} di-resolve dari SecretsProvider dan otomatis di-redact dari log & event.
+// {{env.NAME}} dibaca dari ENV FLOW_ENV_NAME untuk config non-rahasia (base URL, region, dll);
+// env proses lain (kredensial Kafka, API_KEYS, DB) tidak bisa dibaca dari flow.
+// Filter {{path | default "x"}} dipakai bila value tidak ditemukan; tanpa default,
+// env yang tidak di-set jadi string kosong dan path lain dibiarkan literal.
+// Hanya untuk template milik flow (node.Parameters); data caller/upstream pakai renderUntrusted.
 func RenderTemplate(input map[string]interface{}, data map[string]interface{}) map[string]interface{} {
+	return renderTemplate(input, data, true)
+}
+
+// renderUntrusted merender data yang bukan ditulis author flow (input caller, output upstream):
+// {{secret.*}}/{{env.*}} dibiarkan literal supaya caller tidak bisa menyelundupkan placeholder secret.
+func renderUntrusted(input map[string]interface{}, data map[string]interface{}) map[string]interface{} {
+	return renderTemplate(input, data, false)
+}
+
+func renderTemplate(input map[string]interface{}, data map[string]interface{}, trusted bool) map[string]interface{} {
 	// DEBUG: Print context and template
-	fmt.Printf("DEBUG RenderTemplate - Input: %+v\n", input)
-	fmt.Printf("DEBUG RenderTemplate - Data: %+v\n", data)
-	
-	re := regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_\.]+)\s*\}\}`)
+	debugf("DEBUG RenderTemplate - Input: %+v\n", input)
+	debugf("DEBUG RenderTemplate - Data: %+v\n", data)
+
 	rendered := make(map[string]interface{})
 	for key, val := range input {
-		switch str := val.(type) {
-		case string:
-			matches := re.FindAllStringSubmatch(str, -1)
-			newVal := str
-			for _, match := range matches {
-				if len(match) == 2 {
-					lookupPath := match[1]
-					if replacement, ok := getNestedValue(data, lookupPath); ok {
-						newVal = strings.ReplaceAll(newVal, match[0], fmt.Sprintf("%v", replacement))
-					}
-				}
-			}
-			rendered[key] = newVal
-		default:
+		if str, ok := val.(string); ok {
+			rendered[key] = renderString(str, data, trusted)
+		} else {
 			rendered[key] = val
 		}
 	}
 	return rendered
 }
 
+// renderString mengganti placeholder dalam satu pass: value hasil substitusi tidak di-scan ulang,
+// jadi "{{secret.X}}" yang datang dari context tetap literal.
+func renderString(str string, data map[string]interface{}, trusted bool) string {
+	return templatePattern.ReplaceAllStringFunc(str, func(placeholder string) string {
+		match := templatePattern.FindStringSubmatch(placeholder)
+		lookupPath := match[1]
+		hasDefault := match[2] != ""
+		if !trusted && (strings.HasPrefix(lookupPath, "secret.") || strings.HasPrefix(lookupPath, "env.")) {
+			return placeholder
+		}
+		// {{secret.NAME}} dari secrets provider, bukan dari context flow
+		if name, ok := strings.CutPrefix(lookupPath, "secret."); ok {
+			if secret, ok := resolveSecret(name); ok {
+				return secret
+			}
+			if hasDefault {
+				return match[3]
+			}
+			return placeholder
+		}
+		// {{env.NAME}}: env kosong/tidak ada → default filter, atau string kosong
+		if name, ok := strings.CutPrefix(lookupPath, "env."); ok {
+			value, ok := lookupFlowEnv(name)
+			if !ok && hasDefault {
+				value = match[3]
+			}
+			return value
+		}
+		if replacement, ok := getNestedValue(data, lookupPath); ok {
+			return fmt.Sprintf("%v", replacement)
+		}
+		if hasDefault {
+			return match[3]
+		}
+		return placeholder
+	})
+}
+
+// flowEnvPrefix: hanya env dengan prefix ini yang bisa dibaca lewat {{env.NAME}}
+const flowEnvPrefix = "FLOW_ENV_"
+
+// lookupFlowEnv: {{env.REGION}} → ENV FLOW_ENV_REGION. Flow bisa di-upload oleh pemegang API key,
+// jadi env proses tidak boleh terbaca langsung (output StaticReply tidak di-redact).
+func lookupFlowEnv(name string) (string, bool) {
+	return os.LookupEnv(flowEnvPrefix + strings.TrimPrefix(name, flowEnvPrefix))
+}
+
 // getNestedValue mencari nilai berdasarkan path seperti "input.message" dalam map bersarang.
 func getNestedValue(data map[string]interface{}, path string) (interface{}, bool) {
-	fmt.Printf("DEBUG getNestedValue - Path: %s\n", path)
-	fmt.Printf("DEBUG getNestedValue - Data keys: %v\n", getMapKeys(data))
+	debugf("DEBUG getNestedValue - Path: %s\n", path)
+	debugf("DEBUG getNestedValue - Data keys: %v\n", getMapKeys(data))
 	
 	keys := strings.Split(path, ".")
 	var current interface{} = data
 	for i, key := range keys {
-		fmt.Printf("DEBUG getNestedValue - Step %d, looking for key: %s\n", i, key)
+		debugf("DEBUG getNestedValue - Step %d, looking for key: %s\n", i, key)
 		if m, ok := current.(map[string]interface{}); ok {
 			if val, exists := m[key]; exists {
-				fmt.Printf("DEBUG getNestedValue - Found: %v\n", val)
+				debugf("DEBUG getNestedValue - Found: %v\n", val)
 				current = val
 			} else {
-				fmt.Printf("DEBUG getNestedValue - Key not found: %s\n", key)
+				debugf("DEBUG getNestedValue - Key not found: %s\n", key)
 				return nil, false
 			}
 		} else {
-			fmt.Printf("DEBUG getNestedValue - Not a map: %T\n", current)
+			debugf("DEBUG getNestedValue - Not a map: %T\n", current)
 			return nil, false
 		}
 	}
@@ -67,4 +124,11 @@ func getMapKeys(m map[string]interface{}) []string {
 		keys = append(keys, k)
 	}
 	return keys
-}
\ No newline at end of file
+}
+
+// debugf mencetak trace templating hanya jika FLOW_DEBUG=true
+func debugf(format string, args ...interface{}) {
+	if utils.DebugEnabled() {
+		fmt.Printf(format, args...)
+	}
+}
@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrTenantForbidden: tenant tidak dikenal atau dinonaktifkan (di-map ke HTTP 403)
+var ErrTenantForbidden = errors.New("tenant forbidden")
+
+// TenantConfig adalah setting tenant yang dimuat sebelum flow jalan
+type TenantConfig struct {
+	ID                 string   `json:"id"`
+	Alias              string   `json:"alias"`
+	DisplayName        string   `json:"display_name,omitempty"`
+	Status             string   `json:"status,omitempty"`
+	EnabledChannels    []string `json:"enabled_channels,omitempty"`
+	RateLimitPerMinute int      `json:"rate_limit_per_minute,omitempty"`
+	MaxConcurrentFlows int      `json:"max_concurrent_flows,omitempty"`
+
+	// Default tuning RAG tenant, dipakai jika node tidak men-set parameternya (lihat rag_options.go)
+	RagSimilarityThreshold float64 `json:"rag_similarity_threshold,omitempty"`
+	RagTopK                int     `json:"rag_top_k,omitempty"`
+	RagMaxContentLength    int     `json:"rag_max_content_length,omitempty"`
+}
+
+// TenantProvider mengambil config tenant; harus mengembalikan error yang membungkus
+// ErrTenantForbidden untuk tenant unknown/disabled.
+type TenantProvider func(ctx context.Context, tenantID string) (*TenantConfig, error)
+
+// tenantProvider di-inject dari main (delivery.GetTenant) karena executor tidak boleh import delivery
+var tenantProvider TenantProvider
+
+// SetTenantProvider memasang lookup tenant; nil = validasi tenant dimatikan
+func SetTenantProvider(p TenantProvider) {
+	tenantProvider = p
+}
+
+// loadTenant memvalidasi TenantID flow dan menyisipkan config tenant ke FlowContext.
+// Flow tanpa tenant_id, atau tanpa provider terpasang, dilewati.
+func loadTenant(ctx context.Context, flow *FlowSpec) error {
+	if tenantProvider == nil || flow.Context.TenantID == "" {
+		return nil
+	}
+	tenant, err := tenantProvider(ctx, flow.Context.TenantID)
+	if err != nil {
+		return fmt.Errorf("tenant %s: %w", flow.Context.TenantID, err)
+	}
+	flow.Context.Tenant = tenant
+	return nil
+}
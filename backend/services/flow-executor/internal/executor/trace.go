@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"context"
+	"sync"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// NodeError adalah error satu node yang dicatat di Trace (mode continue_on_error)
+type NodeError struct {
+	NodeID string `json:"node_id"`
+	Hoop   string `json:"hoop"`
+	Error  string `json:"error"`
+}
+
+// Trace mengumpulkan error per node selama satu eksekusi.
+// ContinueOnError hanya untuk debugging: node gagal diganti output kosong dan flow lanjut.
+type Trace struct {
+	ContinueOnError bool
+
+	mu     sync.Mutex
+	errors []NodeError
+}
+
+type traceKey struct{}
+
+// WithTrace memasang trace ke ctx; dibaca engine lewat TraceFromContext
+func WithTrace(ctx context.Context, t *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, t)
+}
+
+// TraceFromContext mengembalikan trace dari ctx, nil jika tidak ada
+func TraceFromContext(ctx context.Context) *Trace {
+	t, _ := ctx.Value(traceKey{}).(*Trace)
+	return t
+}
+
+// Errors mengembalikan salinan error node yang tercatat
+func (t *Trace) Errors() []NodeError {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]NodeError(nil), t.errors...)
+}
+
+func (t *Trace) recordError(node Node, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errors = append(t.errors, NodeError{NodeID: node.ID, Hoop: node.Hoop, Error: err.Error()})
+}
+
+// continueAfterError: di mode continue_on_error, error node dicatat di trace, output node
+// diganti map kosong, dan mengembalikan node berikutnya (fall-through). Default (fail-fast) → false.
+func continueAfterError(ctx context.Context, flow FlowSpec, node Node, err error, outputs map[string]map[string]interface{}) (string, bool) {
+	t := TraceFromContext(ctx)
+	if t == nil || !t.ContinueOnError {
+		return "", false
+	}
+	t.recordError(node, err)
+	utils.FromContext(ctx).Warn().Err(err).Str("node_id", node.ID).Msg("⏭️ Node gagal, lanjut (continue_on_error)")
+
+	empty := map[string]interface{}{}
+	outputs[node.ID] = empty
+	flow.Context.Outputs[node.ID] = empty
+	return getNextNodeID(flow.Nodes, node.ID), true
+}
@@ -0,0 +1,48 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// handleTransform membentuk output baru dari parameters["map"] (target key → template).
+// Template di-resolve terhadap context penuh, jadi bisa ambil dari beberapa node upstream
+// lewat ID-nya, mis. {"menu": "{{show_menu.menu}}", "order": "{{create_order.order_id}}"}.
+// Template yang hanya berisi satu placeholder mempertahankan tipe aslinya (map, angka, dll).
+func handleTransform(ctx context.Context, flow FlowSpec, node Node, input map[string]interface{}) (map[string]interface{}, string, error) {
+	mapping, ok := input["map"].(map[string]interface{})
+	if !ok {
+		return nil, "", fmt.Errorf("node %s: parameter map wajib berupa object (target → template)", node.ID)
+	}
+
+	// map dari output upstream (tanpa parameters.map) bukan template milik author flow
+	_, trusted := node.Parameters["map"]
+	contextMap := flow.ContextToMap()
+	output := make(map[string]interface{}, len(mapping))
+	for target, spec := range mapping {
+		tmpl, ok := spec.(string)
+		if !ok {
+			// Nilai non-string dipakai apa adanya (konstanta)
+			output[target] = spec
+			continue
+		}
+		output[target] = resolveTransformValue(tmpl, contextMap, trusted)
+	}
+	return output, node.TruePath, nil
+}
+
+// resolveTransformValue: "{{a.b}}" utuh → value mentah dari context, selain itu dirender sebagai string.
+// trusted=false untuk template yang bukan dari node.Parameters ({{secret.*}}/{{env.*}} tidak di-resolve).
+func resolveTransformValue(tmpl string, contextMap map[string]interface{}, trusted bool) interface{} {
+	trimmed := strings.TrimSpace(tmpl)
+	if m := templatePattern.FindStringSubmatch(trimmed); m != nil && m[0] == trimmed && m[2] == "" {
+		path := m[1]
+		if !strings.HasPrefix(path, "secret.") && !strings.HasPrefix(path, "env.") {
+			if val, ok := getNestedValue(contextMap, path); ok {
+				return val
+			}
+		}
+	}
+	return renderString(tmpl, contextMap, trusted)
+}
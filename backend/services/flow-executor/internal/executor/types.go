@@ -1,13 +1,16 @@
 package executor
 
-import "fmt"
-
 type FlowContext struct {
-	UserID    string                 `json:"user_id"`
-	TenantID  string                 `json:"tenant_id"`
-	Input     map[string]interface{} `json:"input"`               // ✅ Untuk inject input user
-	Outputs   map[string]interface{} `json:"outputs,omitempty"`   // ✅ Output antar node (untuk template seperti {{fetch_answer.answer}})
-	SessionID string                 `json:"session_id,omitempty"` // optional, untuk trace
+	UserID      string                 `json:"user_id"`
+	TenantID    string                 `json:"tenant_id"`
+	Input       map[string]interface{} `json:"input"`                  // ✅ Untuk inject input user
+	Outputs     map[string]interface{} `json:"outputs,omitempty"`      // ✅ Output antar node (untuk template seperti {{fetch_answer.answer}})
+	SessionID   string                 `json:"session_id,omitempty"`   // optional, untuk trace
+	TraceID     string                 `json:"trace_id,omitempty"`     // dikirim sebagai Kafka header untuk korelasi end-to-end
+	ExecutionID string                 `json:"execution_id,omitempty"` // unik per eksekusi RunFlow, untuk korelasi log
+	Tenant      *TenantConfig          `json:"tenant,omitempty"`       // config tenant dari TenantManager, diisi sebelum run
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`     // bebas (channel, locale, dll), tidak ikut Input
+	Vars        map[string]interface{} `json:"vars,omitempty"`         // variabel flow, ditulis node SetVar
 }
 
 type Node struct {
@@ -22,19 +25,24 @@ type Node struct {
 }
 
 type FlowSpec struct {
-	FlowID    string      `json:"flow_id"`
-	TriggerID string      `json:"trigger_id"`
-	Context   FlowContext `json:"context"`
-	Nodes     []Node      `json:"nodes"`
+	FlowID      string       `json:"flow_id"`
+	TriggerID   string       `json:"trigger_id"`
+	Context     FlowContext  `json:"context"`
+	Nodes       []Node       `json:"nodes"`
+	StartNode   string       `json:"start_node,omitempty"` // opsional; default entry node hasil deteksi graph (lihat EntryNode)
+	InputSchema *InputSchema `json:"input_schema,omitempty"` // opsional, divalidasi sebelum node pertama jalan
 }
 
 // Type alias agar bisa dipanggil dari main.go
 type Flow = FlowSpec
 
-// ✅ Patch final agar input + outputs bisa dirender via template
+// ✅ Patch final agar input + outputs bisa dirender via template.
+// Precedence saat nama bentrok (kanan menang):
+// user_id/tenant_id/session_id < metadata < input < vars < outputs.
+// Metadata & vars juga selalu bisa diakses eksplisit lewat {{metadata.x}} / {{vars.x}}.
 func (f FlowSpec) ContextToMap() map[string]interface{} {
-	fmt.Printf("DEBUG ContextToMap - TenantID value: '%s'\n", f.Context.TenantID)
-	fmt.Printf("DEBUG ContextToMap - UserID value: '%s'\n", f.Context.UserID)
+	debugf("DEBUG ContextToMap - TenantID value: '%s'\n", f.Context.TenantID)
+	debugf("DEBUG ContextToMap - UserID value: '%s'\n", f.Context.UserID)
 	
 	context := map[string]interface{}{
 		"user_id":    f.Context.UserID,
@@ -42,16 +50,28 @@ func (f FlowSpec) ContextToMap() map[string]interface{} {
 		"session_id": f.Context.SessionID,
 	}
 	
+	for key, value := range f.Context.Metadata {
+		context[key] = value
+	}
+
 	// Flatten input content directly to root context
 	for key, value := range f.Context.Input {
 		context[key] = value
 	}
-	
+
+	for key, value := range f.Context.Vars {
+		context[key] = value
+	}
+
 	// Inject outputs sebagai key langsung ke context map
 	for nodeID, output := range f.Context.Outputs {
 		context[nodeID] = output
 	}
+
+	// Namespace eksplisit, di-set terakhir supaya tidak tertimpa key flatten
+	context["metadata"] = copyMap(f.Context.Metadata)
+	context["vars"] = copyMap(f.Context.Vars)
 	
-	fmt.Printf("DEBUG ContextToMap - Final context tenant_id: '%v'\n", context["tenant_id"])
+	debugf("DEBUG ContextToMap - Final context tenant_id: '%v'\n", context["tenant_id"])
 	return context
 }
\ No newline at end of file
@@ -1,5 +1,22 @@
 package executor
 
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
 // MergeContextAndInput menggabungkan context map dan input user.
 // Input dimasukkan sebagai nested key "input" agar bisa diakses via {{input.xxx}}.
 func MergeContextAndInput(contextMap map[string]interface{}, input map[string]interface{}) map[string]interface{} {
@@ -15,3 +32,171 @@ func MergeContextAndInput(contextMap map[string]interface{}, input map[string]in
 
 	return merged
 }
+
+// newTraceID membuat trace_id acak (hex 16 byte) untuk satu eksekusi flow.
+func newTraceID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+type executionIDKey struct{}
+
+// NewExecutionID membuat UUID v4 untuk satu eksekusi flow.
+func NewExecutionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// WithExecutionID menyisipkan execution_id ke ctx, supaya caller (mis. HTTP handler) tahu ID-nya.
+func WithExecutionID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, executionIDKey{}, id)
+}
+
+// ExecutionIDFromContext mengambil execution_id dari ctx, "" jika tidak ada.
+func ExecutionIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(executionIDKey{}).(string)
+	return id
+}
+
+// eventHeaders menyusun header Kafka untuk event flow (trace_id + tenant_id).
+func eventHeaders(flow FlowSpec) map[string]string {
+	return map[string]string{
+		"trace_id":  flow.Context.TraceID,
+		"tenant_id": flow.Context.TenantID,
+	}
+}
+
+// publishFlowCompleted mengirim event "flow completed" sekali di akhir eksekusi (sukses maupun gagal)
+func publishFlowCompleted(ctx context.Context, flow FlowSpec, status string, duration time.Duration, output map[string]interface{}) {
+	if status == "fail" {
+		// Flow gagal tidak punya output final (output node terakhir yang sukses bukan hasil flow)
+		output = nil
+	}
+	event := map[string]interface{}{
+		"event":        "flow_completed",
+		"flow_id":      flow.FlowID,
+		"execution_id": flow.Context.ExecutionID,
+		"status":       status,
+		"duration_ms":  duration.Milliseconds(),
+		"output":       output,
+		"user_id":      flow.Context.UserID,
+		"tenant_id":    flow.Context.TenantID,
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if err := observer.PublishFlowCompleted(flow.Context.ExecutionID, []byte(utils.Redact(string(b))), eventHeaders(flow)); err != nil {
+		utils.FromContext(ctx).Warn().Err(err).Msg("⚠️ Gagal publish event flow_completed")
+	}
+}
+
+// eventMessageID membuat message_id yang stabil per event (trace_id + urutan node),
+// dipakai notification-service untuk deduplikasi.
+func eventMessageID(flow FlowSpec, step int) string {
+	return fmt.Sprintf("%s-%d", flow.Context.TraceID, step)
+}
+
+// classifyNodeError mengelompokkan error node untuk label metric: timeout, grpc, atau validation.
+func classifyNodeError(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	if errors.Is(err, ragclient.ErrRAGUnavailable) {
+		return "grpc"
+	}
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) {
+		if se.GRPCStatus().Code() == codes.DeadlineExceeded {
+			return "timeout"
+		}
+		return "grpc"
+	}
+	return "validation"
+}
+
+// recordNodeError mencatat kegagalan node ke NodeExecutionErrors.
+func recordNodeError(node Node, err error) {
+	observer.NodeExecutionErrors.WithLabelValues(node.ID, node.Hoop, classifyNodeError(err)).Inc()
+}
+
+// isolateContext menyalin map Input/Outputs supaya eksekusi paralel dari FlowSpec yang sama
+// (mis. flow yang di-cache, beda tenant) tidak saling menulis ke map yang sama.
+func isolateContext(flow *FlowSpec) {
+	input := make(map[string]interface{}, len(flow.Context.Input))
+	for k, v := range flow.Context.Input {
+		input[k] = v
+	}
+	flow.Context.Input = input
+
+	outputs := make(map[string]interface{}, len(flow.Context.Outputs))
+	for k, v := range flow.Context.Outputs {
+		outputs[k] = v
+	}
+	flow.Context.Outputs = outputs
+
+	// Vars ditulis node SetVar selama eksekusi, jadi selalu map baru per eksekusi
+	flow.Context.Metadata = copyMap(flow.Context.Metadata)
+	flow.Context.Vars = copyMap(flow.Context.Vars)
+}
+
+// copyMap membuat salinan shallow; nil menjadi map kosong
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// buildNodeInput menyusun dan merender input node.
+// Urutan prioritas: output node InputFrom sebagai dasar, lalu Parameters menimpa key yang sama,
+// jadi node bisa memakai hasil upstream (mis. menu_id dari ShowMenu) sekaligus parameter statis.
+// Hanya Parameters (ditulis author flow) yang boleh me-resolve {{secret.*}}/{{env.*}};
+// output upstream bisa berisi data caller, jadi dirender tanpa namespace itu.
+func buildNodeInput(node Node, outputs map[string]map[string]interface{}, contextMap map[string]interface{}) (map[string]interface{}, error) {
+	input := make(map[string]interface{}, len(node.Parameters))
+	if node.InputFrom != "" {
+		ref, ok := outputs[node.InputFrom]
+		if !ok {
+			return nil, fmt.Errorf("node %s: missing input from %s", node.ID, node.InputFrom)
+		}
+		for k, v := range renderUntrusted(ref, contextMap) {
+			input[k] = v
+		}
+	}
+	for k, v := range RenderTemplate(node.Parameters, contextMap) {
+		input[k] = v
+	}
+	return input, nil
+}
+
+// stringSliceParam mengubah parameter array (dari JSON: []interface{}) menjadi []string
+func stringSliceParam(v interface{}) ([]string, error) {
+	switch vals := v.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return vals, nil
+	case []interface{}:
+		out := make([]string, 0, len(vals))
+		for _, item := range vals {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected string array, got element %T", item)
+			}
+			out = append(out, str)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected string array, got %T", v)
+	}
+}
@@ -0,0 +1,158 @@
+package executor
+
+import "fmt"
+
+// FlowValidation adalah hasil dry-run: masalah struktur + urutan eksekusi yang ter-resolve.
+type FlowValidation struct {
+	Valid          bool     `json:"valid"`
+	Problems       []string `json:"problems"`
+	Warnings       []string `json:"warnings,omitempty"`
+	ExecutionOrder []string `json:"execution_order"`
+}
+
+// ValidateFlow memeriksa referensi antar node dan loop tanpa jalan keluar, lalu
+// menghitung urutan eksekusi secara statis. Tidak ada node yang dieksekusi.
+func ValidateFlow(flow FlowSpec) FlowValidation {
+	result := FlowValidation{Problems: []string{}, ExecutionOrder: []string{}}
+	problem := func(format string, args ...interface{}) {
+		result.Problems = append(result.Problems, fmt.Sprintf(format, args...))
+	}
+	warn := func(format string, args ...interface{}) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	if len(flow.Nodes) == 0 {
+		problem("flow '%s' tidak memiliki node", flow.FlowID)
+		return result
+	}
+
+	index := make(map[string]int, len(flow.Nodes))
+	for i, n := range flow.Nodes {
+		switch {
+		case n.ID == "":
+			problem("nodes[%d]: id kosong", i)
+			continue
+		case n.Hoop == "":
+			warn("node %s: hoop kosong, node akan dilewati", n.ID)
+		case !isKnownHoop(n.Hoop):
+			problem("node %s: unknown hoop %s (tidak terdaftar di HoopRegistry)", n.ID, n.Hoop)
+		}
+		if first, dup := index[n.ID]; dup {
+			problem("node %s: id duplikat (pertama di nodes[%d])", n.ID, first)
+			continue
+		}
+		index[n.ID] = i
+	}
+
+	for _, n := range flow.Nodes {
+		refs := []struct{ name, target string }{
+			{"input_from", n.InputFrom},
+			{"true_path", n.TruePath},
+			{"false_path", n.FalsePath},
+			{"jump_to", n.JumpTo},
+		}
+		for _, ref := range refs {
+			if ref.target == "" {
+				continue
+			}
+			if _, ok := index[ref.target]; !ok {
+				problem("node %s: %s menunjuk node yang tidak ada (%s)", n.ID, ref.name, ref.target)
+			}
+		}
+	}
+
+	if flow.InputSchema != nil {
+		for field, prop := range flow.InputSchema.Properties {
+			if prop.Type != "" && !schemaTypes[prop.Type] {
+				problem("input_schema: field %s punya tipe tidak dikenal %q", field, prop.Type)
+			}
+		}
+	}
+
+	// Walk statis mengikuti aturan engine; cabang IfNode dikunjungi true_path dulu
+	visited := make(map[string]bool)
+	onPath := make(map[string]bool)
+	var path []string
+	var walk func(id string)
+	walk = func(id string) {
+		if _, ok := index[id]; !ok {
+			return
+		}
+		if onPath[id] {
+			reportCycle(flow, index, path, id, problem, warn)
+			return
+		}
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		onPath[id] = true
+		path = append(path, id)
+		result.ExecutionOrder = append(result.ExecutionOrder, id)
+
+		for _, next := range successors(flow.Nodes, index[id]) {
+			walk(next)
+		}
+
+		path = path[:len(path)-1]
+		onPath[id] = false
+	}
+	entryID, err := EntryNode(flow)
+	if err != nil {
+		problem("%v", err)
+		entryID = flow.Nodes[0].ID
+	}
+	walk(entryID)
+
+	for _, n := range flow.Nodes {
+		if n.ID != "" && !visited[n.ID] {
+			warn("node %s: tidak pernah tercapai dari entry node %s", n.ID, entryID)
+		}
+	}
+
+	result.Valid = len(result.Problems) == 0
+	return result
+}
+
+// successors mengembalikan node berikutnya persis seperti loop di RunFlowContext
+func successors(nodes []Node, i int) []string {
+	node := nodes[i]
+	next := ""
+	if i+1 < len(nodes) {
+		next = nodes[i+1].ID
+	}
+
+	switch {
+	case node.Hoop == "":
+		return []string{next}
+	case node.Hoop == "IfNode":
+		return []string{node.TruePath, node.FalsePath}
+	case node.TruePath != "":
+		next = node.TruePath
+	}
+	// Hoop seperti CheckStock bisa bercabang ke false_path tanpa IfNode
+	if node.FalsePath != "" {
+		return []string{next, node.FalsePath}
+	}
+	return []string{next}
+}
+
+// reportCycle: loop tanpa IfNode pasti infinite (error), loop lewat IfNode hanya warning
+func reportCycle(flow FlowSpec, index map[string]int, path []string, start string, problem, warn func(string, ...interface{})) {
+	var cycle []string
+	for i := len(path) - 1; i >= 0; i-- {
+		cycle = append([]string{path[i]}, cycle...)
+		if path[i] == start {
+			break
+		}
+	}
+	cycle = append(cycle, start)
+
+	for _, id := range cycle {
+		if flow.Nodes[index[id]].Hoop == "IfNode" {
+			warn("loop kondisional: %v", cycle)
+			return
+		}
+	}
+	problem("infinite loop: %v", cycle)
+}
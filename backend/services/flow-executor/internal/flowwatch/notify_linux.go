@@ -0,0 +1,98 @@
+//go:build linux
+
+package flowwatch
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// Event yang relevan: file selesai ditulis, dibuat, dihapus, atau di-rename masuk/keluar
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM | syscall.IN_DELETE_SELF
+
+// inotifyNotifier membaca event inotify (kernel Linux) untuk direktori flow
+type inotifyNotifier struct {
+	file   *os.File
+	dirs   map[int32]string
+	events chan string
+}
+
+// newNotifier memasang watch inotify di setiap direktori yang ada.
+// Direktori yang belum ada dilewati (dikembalikan di missing).
+func newNotifier(dirs []string) (notifier, []string, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := &inotifyNotifier{
+		// fd non-blocking → Read lewat netpoller Go, Close membangunkan Read yang sedang menunggu
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		dirs:   map[int32]string{},
+		events: make(chan string, 64),
+	}
+
+	var missing []string
+	for _, dir := range dirs {
+		wd, err := syscall.InotifyAddWatch(fd, dir, inotifyMask)
+		if err != nil {
+			missing = append(missing, dir)
+			continue
+		}
+		n.dirs[int32(wd)] = dir
+	}
+	if len(n.dirs) == 0 {
+		n.file.Close()
+		return nil, missing, errors.New("tidak ada direktori flow yang bisa dipantau")
+	}
+
+	go n.read()
+	return n, missing, nil
+}
+
+func (n *inotifyNotifier) Events() <-chan string { return n.events }
+
+func (n *inotifyNotifier) Close() error { return n.file.Close() }
+
+func (n *inotifyNotifier) read() {
+	defer close(n.events)
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= count; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			nameEnd := nameStart + int(ev.Len)
+			if nameEnd > count {
+				break
+			}
+			name := string(trimNul(buf[nameStart:nameEnd]))
+			offset = nameEnd
+
+			path := n.dirs[ev.Wd]
+			if name != "" {
+				path = filepath.Join(path, name)
+			}
+			// Channel penuh → diff snapshot sudah pasti dijadwalkan, event boleh di-drop
+			select {
+			case n.events <- path:
+			default:
+			}
+		}
+	}
+}
+
+func trimNul(b []byte) []byte {
+	for i, c := range b {
+		if c == 0 {
+			return b[:i]
+		}
+	}
+	return b
+}
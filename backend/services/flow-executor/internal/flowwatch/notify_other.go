@@ -0,0 +1,10 @@
+//go:build !linux
+
+package flowwatch
+
+import "errors"
+
+// newNotifier: event filesystem hanya didukung di Linux (inotify); OS lain fallback ke polling
+func newNotifier(dirs []string) (notifier, []string, error) {
+	return nil, nil, errors.New("file event watcher hanya tersedia di Linux")
+}
@@ -0,0 +1,279 @@
+package flowwatch
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/loader"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Interval polling default (fallback jika event filesystem tidak tersedia),
+// override via ENV FLOW_WATCH_INTERVAL (durasi Go, mis. "5s")
+const defaultInterval = 2 * time.Second
+
+// debounce: event beruntun (editor menulis temp file + rename) digabung jadi satu diff
+const debounce = 100 * time.Millisecond
+
+// notifier adalah sumber event filesystem (inotify di Linux); path yang dikirim hanya
+// pemicu, perubahan sebenarnya ditentukan dari diff snapshot
+type notifier interface {
+	Events() <-chan string
+	Close() error
+}
+
+// InvalidateFunc dipanggil untuk setiap file flow yang berubah / dihapus
+type InvalidateFunc func(path string)
+
+var (
+	invalidatorsMu sync.RWMutex
+	invalidators   []InvalidateFunc
+)
+
+// OnInvalidate mendaftarkan hook invalidasi cache (mis. cache flow / .pb di memori)
+func OnInvalidate(fn InvalidateFunc) {
+	invalidatorsMu.Lock()
+	defer invalidatorsMu.Unlock()
+	invalidators = append(invalidators, fn)
+}
+
+func invalidate(path string) {
+	invalidatorsMu.RLock()
+	defer invalidatorsMu.RUnlock()
+	for _, fn := range invalidators {
+		fn(path)
+	}
+}
+
+// FlowStatus adalah hasil validasi ulang satu file flow
+type FlowStatus struct {
+	Path     string   `json:"path"`
+	FlowID   string   `json:"flow_id,omitempty"`
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher memantau direktori flow lewat event filesystem (inotify); setiap event memicu
+// diff snapshot (mtime + size). Di OS tanpa inotify, fallback ke polling tiap interval.
+// Dibuat lewat Start dan dihentikan dengan cancel ctx + Wait.
+type Watcher struct {
+	dirs     []string
+	interval time.Duration
+	notifier notifier
+
+	mu    sync.Mutex
+	files map[string]stamp
+	wg    sync.WaitGroup
+}
+
+// Start men-snapshot direktori flow lalu menjalankan loop event (atau polling) di goroutine.
+// FLOW_WATCH=false menonaktifkan loop; Reload manual tetap bisa dipakai.
+func Start(ctx context.Context) *Watcher {
+	logger := utils.Component("flowwatch")
+
+	interval := defaultInterval
+	if v := os.Getenv("FLOW_WATCH_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			interval = d
+		} else {
+			logger.Warn().Str("value", v).Msg("⚠️ FLOW_WATCH_INTERVAL tidak valid, pakai default")
+		}
+	}
+
+	w := &Watcher{
+		dirs:     []string{loader.ExamplesDir(), loader.GlobalDir(), loader.CompiledDir()},
+		interval: interval,
+		files:    map[string]stamp{},
+	}
+	w.files = w.snapshot()
+
+	if os.Getenv("FLOW_WATCH") == "false" {
+		logger.Info().Msg("👀 Flow watcher nonaktif (FLOW_WATCH=false)")
+		return w
+	}
+
+	n, missing, err := newNotifier(w.dirs)
+	if err != nil {
+		logger.Warn().Err(err).Dur("interval", interval).Msg("⚠️ File event watcher tidak tersedia, fallback ke polling")
+		w.wg.Add(1)
+		go w.pollLoop(ctx)
+		return w
+	}
+	if len(missing) > 0 {
+		logger.Warn().Strs("dirs", missing).Msg("⚠️ Direktori flow belum ada, tidak dipantau (pakai POST /flows/reload)")
+	}
+	w.notifier = n
+
+	w.wg.Add(1)
+	go w.eventLoop(ctx)
+
+	logger.Info().Strs("dirs", w.dirs).Msg("👀 Flow watcher started (inotify)")
+	return w
+}
+
+// Wait menunggu loop watcher selesai (dipanggil setelah ctx di-cancel)
+func (w *Watcher) Wait() {
+	if w != nil {
+		w.wg.Wait()
+	}
+}
+
+// eventLoop menjalankan diff snapshot setiap kali ada event filesystem (setelah debounce)
+func (w *Watcher) eventLoop(ctx context.Context) {
+	defer w.wg.Done()
+	defer w.notifier.Close()
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	events := w.notifier.Events()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-events:
+			if !ok {
+				utils.Component("flowwatch").Warn().Msg("⚠️ File event watcher berhenti")
+				return
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			w.poll()
+		}
+	}
+}
+
+func (w *Watcher) pollLoop(ctx context.Context) {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll membandingkan snapshot baru dengan yang lama, lalu invalidasi + validasi file yang berubah
+func (w *Watcher) poll() {
+	current := w.snapshot()
+
+	w.mu.Lock()
+	previous := w.files
+	w.files = current
+	w.mu.Unlock()
+
+	for path, st := range current {
+		if old, ok := previous[path]; ok && old == st {
+			continue
+		}
+		invalidate(path)
+		if isFlowSource(path) {
+			logStatus(validateFile(path))
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			invalidate(path)
+			utils.Component("flowwatch").Info().Str("path", path).Msg("🗑️ Flow file removed")
+		}
+	}
+}
+
+// Reload meng-invalidasi semua cache dan memvalidasi ulang semua flow (trigger POST /flows/reload)
+func (w *Watcher) Reload() []FlowStatus {
+	current := w.snapshot()
+
+	w.mu.Lock()
+	w.files = current
+	w.mu.Unlock()
+
+	paths := make([]string, 0, len(current))
+	for path := range current {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	statuses := []FlowStatus{}
+	for _, path := range paths {
+		invalidate(path)
+		if !isFlowSource(path) {
+			continue
+		}
+		st := validateFile(path)
+		logStatus(st)
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
+
+func (w *Watcher) snapshot() map[string]stamp {
+	files := map[string]stamp{}
+	for _, dir := range w.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			files[filepath.Join(dir, e.Name())] = stamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return files
+}
+
+// isFlowSource: hanya file JSON/YAML yang divalidasi; .pb cukup di-invalidasi
+func isFlowSource(path string) bool {
+	return filepath.Ext(path) == ".json" || loader.IsYAMLFlow(path)
+}
+
+func validateFile(path string) FlowStatus {
+	st := FlowStatus{Path: path}
+	data, err := loader.ReadFlowJSON(path)
+	if err != nil {
+		st.Problems = []string{err.Error()}
+		return st
+	}
+	var flow executor.FlowSpec
+	if err := json.Unmarshal(data, &flow); err != nil {
+		st.Problems = []string{"invalid JSON: " + err.Error()}
+		return st
+	}
+	result := executor.ValidateFlow(flow)
+	st.FlowID = flow.FlowID
+	st.Valid = result.Valid
+	st.Problems = result.Problems
+	st.Warnings = result.Warnings
+	return st
+}
+
+func logStatus(st FlowStatus) {
+	logger := utils.Component("flowwatch")
+	if !st.Valid {
+		logger.Error().Str("path", st.Path).Strs("problems", st.Problems).Msg("❌ Flow reloaded with validation errors")
+		return
+	}
+	logger.Info().Str("path", st.Path).Str("flow_id", st.FlowID).Int("warnings", len(st.Warnings)).Msg("🔄 Flow reloaded")
+}
@@ -0,0 +1,86 @@
+package inventoryclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	invpb "github.com/milkyhoop/flow-executor/internal/proto/inventory"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+var (
+	invMu     sync.Mutex
+	invConn   *grpc.ClientConn
+	invClient invpb.InventoryServiceClient
+)
+
+// InventoryTarget mengembalikan alamat InventoryService dari ENV INVENTORY_GRPC_HOST/PORT
+func InventoryTarget() string {
+	host := os.Getenv("INVENTORY_GRPC_HOST")
+	port := os.Getenv("INVENTORY_GRPC_PORT")
+	if host == "" {
+		host = "inventory_service"
+	}
+	if port == "" {
+		port = "5001"
+	}
+	return fmt.Sprintf("%s:%s", host, port)
+}
+
+func getClient() (invpb.InventoryServiceClient, error) {
+	invMu.Lock()
+	defer invMu.Unlock()
+
+	if invClient != nil {
+		return invClient, nil
+	}
+	conn, err := grpc.NewClient(InventoryTarget(), grpc.WithTransportCredentials(insecure.NewCredentials()), tracing.DialOption())
+	if err != nil {
+		return nil, fmt.Errorf("❌ Gagal konek ke inventory service: %w", err)
+	}
+	invConn = conn
+	invClient = invpb.NewInventoryServiceClient(conn)
+	return invClient, nil
+}
+
+// resetClient membuang koneksi rusak supaya call berikutnya dial ulang
+func resetClient() {
+	invMu.Lock()
+	defer invMu.Unlock()
+
+	if invConn != nil {
+		invConn.Close()
+	}
+	invConn = nil
+	invClient = nil
+}
+
+// CheckStock mengambil posisi stok SKU dari InventoryService
+func CheckStock(ctx context.Context, sku string) (*invpb.StockResponse, error) {
+	client, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := client.CheckStock(ctx, &invpb.CheckStockRequest{Sku: sku})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			utils.Component("inventoryclient").Warn().Err(err).Str("target", InventoryTarget()).Msg("🔁 Inventory service unavailable, koneksi di-reset")
+			resetClient()
+		}
+		return nil, fmt.Errorf("❌ Gagal cek stok %s: %w", sku, err)
+	}
+	return resp, nil
+}
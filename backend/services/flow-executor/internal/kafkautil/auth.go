@@ -0,0 +1,99 @@
+package kafkautil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// SASLMechanism membaca KAFKA_SASL_MECHANISM (PLAIN, SCRAM-SHA-256, SCRAM-SHA-512).
+// Mengembalikan nil jika tidak diset (plaintext, untuk dev lokal).
+func SASLMechanism() (sasl.Mechanism, error) {
+	mechanism := strings.ToUpper(os.Getenv("KAFKA_SASL_MECHANISM"))
+	username := os.Getenv("KAFKA_SASL_USERNAME")
+	password := os.Getenv("KAFKA_SASL_PASSWORD")
+
+	switch mechanism {
+	case "":
+		return nil, nil
+	case "PLAIN":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("unsupported KAFKA_SASL_MECHANISM: %s", mechanism)
+	}
+}
+
+// TLSConfig membaca KAFKA_TLS_ENABLED dan KAFKA_TLS_CA_FILE (opsional).
+// Mengembalikan nil jika TLS tidak aktif.
+func TLSConfig() (*tls.Config, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv("KAFKA_TLS_ENABLED"))
+	if !enabled {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile := os.Getenv("KAFKA_TLS_CA_FILE"); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read KAFKA_TLS_CA_FILE: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
+
+// Dialer untuk kafka.WriterConfig / kafka.ReaderConfig dengan SASL/TLS dari env.
+// Config SASL/TLS yang invalid dikembalikan sebagai error (tidak fallback ke plaintext).
+func Dialer() (*kafka.Dialer, error) {
+	mechanism, tlsCfg, err := loadAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		SASLMechanism: mechanism,
+		TLS:           tlsCfg,
+	}, nil
+}
+
+// Transport untuk kafka.Writer dengan SASL/TLS dari env.
+func Transport() (*kafka.Transport, error) {
+	mechanism, tlsCfg, err := loadAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Transport{
+		SASL: mechanism,
+		TLS:  tlsCfg,
+	}, nil
+}
+
+func loadAuth() (sasl.Mechanism, *tls.Config, error) {
+	mechanism, err := SASLMechanism()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Kafka SASL config: %w", err)
+	}
+	tlsCfg, err := TLSConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Kafka TLS config: %w", err)
+	}
+	return mechanism, tlsCfg, nil
+}
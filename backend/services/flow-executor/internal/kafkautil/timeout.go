@@ -0,0 +1,34 @@
+package kafkautil
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// PublishTimeout adalah batas waktu satu WriteMessages (ENV KAFKA_PUBLISH_TIMEOUT, default 5s)
+// supaya Kafka yang down tidak membuat eksekusi flow menggantung.
+func PublishTimeout() time.Duration {
+	if v := os.Getenv("KAFKA_PUBLISH_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 5 * time.Second
+}
+
+// Brokers membaca daftar broker dari KAFKA_BROKERS (dipisah koma), fallback ke KAFKA_BROKER.
+// Mengembalikan nil jika keduanya kosong (Kafka tidak aktif).
+func Brokers() []string {
+	raw := os.Getenv("KAFKA_BROKERS")
+	if raw == "" {
+		raw = os.Getenv("KAFKA_BROKER")
+	}
+	var brokers []string
+	for _, b := range strings.Split(raw, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			brokers = append(brokers, b)
+		}
+	}
+	return brokers
+}
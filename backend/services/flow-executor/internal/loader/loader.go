@@ -17,7 +17,7 @@ func CompileJSON(jsonPath, outputPath string) error {
 		compilerURL = "http://visualhoop-compiler:5009/compile"
 	}
 
-	file, err := os.Open(filepath.Join("flows/global", jsonPath))
+	file, err := os.Open(filepath.Join(GlobalDir(), jsonPath))
 	if err != nil {
 		return fmt.Errorf("failed to open JSON file: %w", err)
 	}
@@ -0,0 +1,79 @@
+package loader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var (
+	flowsDir     string
+	flowsDirOnce sync.Once
+)
+
+// FlowsDir adalah root direktori flow (ENV FLOWS_DIR, default "flows"), dibaca sekali.
+// Semua path flow (examples, global, compiled) diturunkan dari sini.
+func FlowsDir() string {
+	flowsDirOnce.Do(func() {
+		flowsDir = os.Getenv("FLOWS_DIR")
+		if flowsDir == "" {
+			flowsDir = "flows"
+		}
+	})
+	return flowsDir
+}
+
+// ExamplesDir berisi flow bawaan; GlobalDir meng-override-nya (lihat ResolveFlowPath)
+func ExamplesDir() string { return filepath.Join(FlowsDir(), "examples") }
+
+// GlobalDir berisi flow yang di-upload / di-deploy, prioritas di atas ExamplesDir
+func GlobalDir() string { return filepath.Join(FlowsDir(), "global") }
+
+// CompiledDir berisi artefak .pb hasil visualhoop-compiler
+func CompiledDir() string { return filepath.Join(FlowsDir(), "compiled") }
+
+// ValidateFlowName menolak nama flow yang bisa keluar dari direktori flow:
+// kosong, absolut, mengandung separator, atau "..".
+func ValidateFlowName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("flow name is empty")
+	case filepath.IsAbs(name), strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("invalid flow name %q: path separators are not allowed", name)
+	case strings.Contains(name, ".."):
+		return fmt.Errorf("invalid flow name %q: '..' is not allowed", name)
+	}
+	return nil
+}
+
+// SafeJoin menggabungkan baseDir dengan nama flow yang sudah divalidasi,
+// lalu memastikan hasilnya tetap di dalam baseDir.
+func SafeJoin(baseDir, name string) (string, error) {
+	if err := ValidateFlowName(name); err != nil {
+		return "", err
+	}
+	base, err := filepath.Abs(baseDir)
+	if err != nil {
+		return "", err
+	}
+	full := filepath.Join(base, filepath.Clean(name))
+	if rel, err := filepath.Rel(base, full); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid flow name %q: resolves outside %s", name, baseDir)
+	}
+	return filepath.Join(baseDir, filepath.Clean(name)), nil
+}
+
+// ResolveFlowPath mencari flow di flows/examples, di-override oleh flows/global jika ada
+func ResolveFlowPath(name string) (string, error) {
+	fullpath, err := SafeJoin(ExamplesDir(), name)
+	if err != nil {
+		return "", err
+	}
+	globalPath, _ := SafeJoin(GlobalDir(), name)
+	if _, err := os.Stat(globalPath); err == nil {
+		fullpath = globalPath
+	}
+	return fullpath, nil
+}
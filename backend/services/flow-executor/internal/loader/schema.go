@@ -0,0 +1,35 @@
+package loader
+
+import (
+	"fmt"
+
+	flowpb "github.com/milkyhoop/flow-executor/internal/proto/flow"
+)
+
+// CurrentSchemaVersion harus sama dengan versi yang di-stamp oleh visualhoop-compiler
+const CurrentSchemaVersion = 2
+
+// MigrateFlow menaikkan flow .pb lama ke skema terbaru secara in-place.
+// Versi 0 (belum ada stamp) diperlakukan sebagai v1; versi di atas CurrentSchemaVersion ditolak.
+func MigrateFlow(flow *flowpb.Flow) error {
+	version := flow.GetSchemaVersion()
+	if version == 0 {
+		version = 1
+	}
+	if version > CurrentSchemaVersion {
+		return fmt.Errorf("unsupported flow schema version %d (executor supports up to %d), please upgrade flow-executor", version, CurrentSchemaVersion)
+	}
+
+	if version == 1 {
+		migrateV1ToV2(flow)
+		version = 2
+	}
+
+	flow.SchemaVersion = version
+	return nil
+}
+
+// migrateV1ToV2: v2 hanya menambahkan stamp schema_version, struktur node tidak berubah
+func migrateV1ToV2(flow *flowpb.Flow) {
+	flow.SchemaVersion = 2
+}
@@ -0,0 +1,60 @@
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// IsYAMLFlow true untuk file flow .yaml / .yml
+func IsYAMLFlow(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// ReadFlowJSON membaca file flow dan selalu mengembalikan JSON.
+// File .yaml/.yml dikonversi YAML→JSON dulu, jadi FlowSpec cukup punya json tag.
+func ReadFlowJSON(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !IsYAMLFlow(path) {
+		return data, nil
+	}
+	return YAMLToJSON(data)
+}
+
+// YAMLToJSON mengubah dokumen YAML menjadi JSON (key map di-string-kan)
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	return json.Marshal(jsonCompatible(doc))
+}
+
+// jsonCompatible mengubah map[interface{}]interface{} dari yaml.v2 menjadi map[string]interface{}
+func jsonCompatible(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[fmt.Sprint(k)] = jsonCompatible(item)
+		}
+		return out
+	case []interface{}:
+		for i, item := range val {
+			val[i] = jsonCompatible(item)
+		}
+		return val
+	}
+	return v
+}
@@ -1,29 +1,151 @@
 package observer
 
 import (
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
+// DefaultDurationBuckets: DefBuckets berhenti di 10s, padahal call RAG LLM sering lebih lama,
+// jadi bucket diperpanjang sampai 60s supaya p95/p99 node lambat tetap terbaca.
+var DefaultDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 45, 60}
+
+// durationBuckets membaca ENV METRICS_DURATION_BUCKETS (detik, dipisah koma, mis. "0.5,1,5,30,120").
+// Dibaca saat package init, jadi harus diset di environment proses (bukan lewat .env).
+func durationBuckets() []float64 {
+	raw := os.Getenv("METRICS_DURATION_BUCKETS")
+	if raw == "" {
+		return DefaultDurationBuckets
+	}
+	var buckets []float64
+	for _, part := range strings.Split(raw, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || v <= 0 {
+			return DefaultDurationBuckets
+		}
+		buckets = append(buckets, v)
+	}
+	sort.Float64s(buckets)
+	return buckets
+}
+
 var (
 	FlowExecutionCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "flow_execution_total",
 			Help: "Total number of flows executed",
 		},
-		[]string{"flow_id", "status"},
+		[]string{"flow_id", "status", "tenant_id"},
+	)
+
+	FlowsInProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "flows_in_progress",
+			Help: "Number of flow executions currently running",
+		},
+		[]string{"flow_id"},
 	)
 
 	NodeExecutionDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "node_execution_duration_seconds",
 			Help:    "Duration of each node execution in seconds",
-			Buckets: prometheus.DefBuckets,
+			Buckets: durationBuckets(),
 		},
-		[]string{"node_id", "hoop"},
+		[]string{"node_id", "hoop", "tenant_id"},
+	)
+
+	FlowExecutionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "flow_execution_duration_seconds",
+			Help:    "End-to-end duration of each flow execution in seconds",
+			Buckets: durationBuckets(),
+		},
+		[]string{"flow_id", "status", "tenant_id"},
+	)
+
+	NodeExecutionErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "node_execution_errors_total",
+			Help: "Total number of failed node executions by error type",
+		},
+		[]string{"node_id", "hoop", "error_type"},
+	)
+
+	FlowsRejectedConcurrency = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flows_rejected_concurrency_total",
+			Help: "Total number of flow executions rejected by the per-tenant concurrency limit",
+		},
+		[]string{"tenant_id"},
+	)
+
+	ScheduledFlowRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scheduled_flow_runs_total",
+			Help: "Total number of flows triggered by the cron scheduler",
+		},
+		[]string{"flow_id", "status"},
+	)
+
+	// NodePayloadBytes: ukuran JSON input (setelah render) & output per node, untuk mencari node
+	// yang membengkakkan payload (mis. FAQ search yang mengembalikan dokumen besar). Bucket 256B - 16MB.
+	NodePayloadBytes = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "node_payload_bytes",
+			Help:    "JSON-marshaled size of each node's input and output in bytes",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 9),
+		},
+		[]string{"node_id", "direction"},
+	)
+
+	NotificationPublishFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "notification_publish_failures_total",
+			Help: "Total number of Kafka publishes that failed or timed out (event dropped, flow continues)",
+		},
+		[]string{"topic"},
 	)
 )
 
+var registerOnce sync.Once
+
+// RegisterMetrics mendaftarkan semua metric ke registry default Prometheus (yang di-serve /metrics).
+// Aman dipanggil berkali-kali (mis. test yang init server berulang).
 func RegisterMetrics() {
-	prometheus.MustRegister(FlowExecutionCount)
-	prometheus.MustRegister(NodeExecutionDuration)
+	registerOnce.Do(func() {
+		if err := RegisterMetricsWith(prometheus.DefaultRegisterer); err != nil {
+			utils.Component("observer").Error().Err(err).Msg("❌ Gagal register metrics")
+		}
+	})
+}
+
+// RegisterMetricsWith mendaftarkan semua metric flow-executor (termasuk RAG) ke reg, mis.
+// prometheus.NewRegistry() terisolasi di test. Metric yang sudah terdaftar di reg dilewati.
+func RegisterMetricsWith(reg prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		FlowExecutionCount,
+		FlowExecutionDuration,
+		FlowsInProgress,
+		NodeExecutionDuration,
+		NodeExecutionErrors,
+		FlowsRejectedConcurrency,
+		ScheduledFlowRuns,
+		NotificationPublishFailures,
+		NodePayloadBytes,
+	}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+			return err
+		}
+	}
+	return ragclient.RegisterMetricsWith(reg)
 }
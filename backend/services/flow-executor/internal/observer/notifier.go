@@ -3,37 +3,81 @@ package observer
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"sync"
 	"time"
 	"google.golang.org/grpc"
 	"github.com/segmentio/kafka-go"
+	"github.com/milkyhoop/flow-executor/internal/kafkautil"
 	pb "github.com/milkyhoop/flow-executor/internal/proto"
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
 var kafkaWriter *kafka.Writer
 var (
 	ragClient pb.RagLlmServiceClient
-	connOnce  sync.Once
+	connMu    sync.Mutex
+
+	ragBreaker     *ragclient.CircuitBreaker
+	ragBreakerOnce sync.Once
 )
 
-func InitKafkaWriter(brokers []string) {
+// InitKafkaWriter membuat writer observer (topic per pesan, mis. flow-completed);
+// event node tetap lewat NotificationSink (default Kafka writer delivery, topic send-notification).
+// Config SASL/TLS invalid dikembalikan sebagai error supaya startup gagal.
+func InitKafkaWriter(brokers []string) error {
+	if len(brokers) == 0 {
+		utils.Component("observer").Warn().Msg("⚠️ Tidak ada broker Kafka, observer writer tidak aktif")
+		return nil
+	}
+	transport, err := kafkautil.Transport()
+	if err != nil {
+		return err
+	}
 	kafkaWriter = &kafka.Writer{
-		Addr:     kafka.TCP(brokers...),
-		Balancer: &kafka.LeastBytes{},
+		Addr:      kafka.TCP(brokers...),
+		Balancer:  &kafka.Hash{},
+		Transport: transport,
 	}
+	utils.Component("observer").Info().Strs("brokers", brokers).Msg("📡 Observer Kafka writer siap")
+	return nil
 }
 
-func PublishKafkaMessage(ctx context.Context, topic string, payload []byte) error {
+// CloseKafkaWriter flush pesan observer yang masih di-buffer lalu menutup writer (dipanggil saat shutdown)
+func CloseKafkaWriter() {
+	if kafkaWriter == nil {
+		return
+	}
+	if err := kafkaWriter.Close(); err != nil {
+		utils.Component("observer").Error().Err(err).Msg("❌ Gagal menutup observer Kafka writer")
+		return
+	}
+	utils.Component("observer").Info().Msg("✅ Observer Kafka writer flushed & closed")
+}
+
+// PublishKafkaMessage mengirim payload ke topic; pesan dengan key sama masuk partisi yang sama.
+// Write dibatasi kafkautil.PublishTimeout; kegagalan di-log + dihitung di notification_publish_failures_total.
+func PublishKafkaMessage(ctx context.Context, topic string, key []byte, payload []byte, headers map[string]string) error {
 	if kafkaWriter == nil {
 		return fmt.Errorf("kafka writer not initialized")
 	}
 	msg := kafka.Message{
-		Topic: topic,
-		Value: payload,
+		Topic:   topic,
+		Key:     key,
+		Value:   payload,
+		Headers: toKafkaHeaders(headers),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, kafkautil.PublishTimeout())
+	defer cancel()
+	if err := kafkaWriter.WriteMessages(ctx, msg); err != nil {
+		NotificationPublishFailures.WithLabelValues(topic).Inc()
+		utils.Component("observer").Warn().Err(err).Str("topic", topic).Msg("⚠️ Publish Kafka gagal, event di-drop")
+		return err
 	}
-	return kafkaWriter.WriteMessages(ctx, msg)
+	return nil
 }
 
 func DummyShowMenu(ctx context.Context, input map[string]interface{}) (map[string]interface{}, error) {
@@ -48,37 +92,95 @@ func DummySendNotification(ctx context.Context, input map[string]interface{}) (m
 	return map[string]interface{}{"status": "sent"}, nil
 }
 
-func LogComplaint(userID string, message string) (string, error) {
+// ComplaintMeta adalah metadata complaint yang bisa diisi dari parameters node LogComplaint
+type ComplaintMeta struct {
+	Product string
+	Source  string
+	Emotion string
+}
+
+// WithDefaults mengisi field kosong dengan default lama (unknown / flow-executor / neutral)
+func (m ComplaintMeta) WithDefaults() ComplaintMeta {
+	if m.Product == "" {
+		m.Product = "unknown"
+	}
+	if m.Source == "" {
+		m.Source = "flow-executor"
+	}
+	if m.Emotion == "" {
+		m.Emotion = "neutral"
+	}
+	return m
+}
+
+func LogComplaint(userID string, message string, meta ComplaintMeta) (string, error) {
 	return "complaint-xyz", nil
 }
 
-func getRagClient() pb.RagLlmServiceClient {
-	connOnce.Do(func() {
-		ragHost := os.Getenv("RAGLLM_GRPC_HOST")
-		ragPort := os.Getenv("RAGLLM_GRPC_PORT")
-		if ragHost == "" {
-			ragHost = "ragllm_service"
-		}
-		if ragPort == "" {
-			ragPort = "5000"
-		}
-		target := fmt.Sprintf("%s:%s", ragHost, ragPort)
-		
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		
-		conn, err := grpc.DialContext(ctx, target, grpc.WithInsecure(), grpc.WithBlock())
+// RagLLMTarget mengembalikan alamat RAG LLM service dari ENV (dipakai juga oleh /readyz)
+func RagLLMTarget() string {
+	ragHost := os.Getenv("RAGLLM_GRPC_HOST")
+	ragPort := os.Getenv("RAGLLM_GRPC_PORT")
+	if ragHost == "" {
+		ragHost = "ragllm_service"
+	}
+	if ragPort == "" {
+		ragPort = "5000"
+	}
+	return fmt.Sprintf("%s:%s", ragHost, ragPort)
+}
+
+// getRagClient dial RAG LLM default sekali; jika dial gagal, error dikembalikan dan
+// dial dicoba lagi di call berikutnya (client tidak pernah nil tanpa error)
+func getRagClient() (pb.RagLlmServiceClient, error) {
+	connMu.Lock()
+	defer connMu.Unlock()
+	if ragClient != nil {
+		return ragClient, nil
+	}
+
+	target := RagLLMTarget()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, target, grpc.WithInsecure(), grpc.WithBlock(), tracing.DialOption())
+	if err != nil {
+		utils.Component("observer").Error().Err(err).Str("target", target).Msg("❌ Gagal konek ke RAG LLM service")
+		return nil, fmt.Errorf("%w: RAG LLM %s: %v", ragclient.ErrRAGUnavailable, target, err)
+	}
+	ragClient = pb.NewRagLlmServiceClient(conn)
+	return ragClient, nil
+}
+
+// SetRagLLMClient mengganti client RAG LLM (mis. mock di test); dial ke RagLLMTarget dilewati
+func SetRagLLMClient(c pb.RagLlmServiceClient) {
+	connMu.Lock()
+	defer connMu.Unlock()
+	ragClient = c
+}
+
+// ragLLMFor memilih client & breaker RAG LLM per tenant: endpoint tenant dari pool ragclient
+// jika di-mapping, selain itu endpoint default (RAGLLM_GRPC_*)
+func ragLLMFor(tenantID string) (pb.RagLlmServiceClient, *ragclient.CircuitBreaker, error) {
+	if addr := ragclient.TenantRagLLMTarget(tenantID); addr != "" {
+		conn, err := ragclient.PooledConn(addr)
 		if err != nil {
-			log.Printf("❌ Gagal konek ke RAG LLM service: %v", err)
-			return
+			return nil, nil, err
 		}
-		ragClient = pb.NewRagLlmServiceClient(conn)
+		return pb.NewRagLlmServiceClient(conn), ragclient.BreakerFor("ragllm", addr), nil
+	}
+	ragBreakerOnce.Do(func() {
+		ragBreaker = ragclient.NewCircuitBreaker("ragllm")
 	})
-	return ragClient
+	client, err := getRagClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, ragBreaker, nil
 }
 
-func QueryRAG(query, tenantID string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func QueryRAG(ctx context.Context, query, tenantID string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	
 	req := &pb.GenerateAnswerRequest{
@@ -86,14 +188,77 @@ func QueryRAG(query, tenantID string) (string, error) {
 		TenantId: tenantID,
 	}
 	
-	res, err := getRagClient().GenerateAnswer(ctx, req)
+	client, breaker, err := ragLLMFor(tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	var res *pb.GenerateAnswerResponse
+	err = breaker.Execute(func() error {
+		return ragclient.ObserveCall("GenerateAnswer", func() error {
+			var callErr error
+			res, callErr = client.GenerateAnswer(ctx, req)
+			return callErr
+		})
+	})
 	if err != nil {
 		return "", fmt.Errorf("❌ Gagal query ke RAG LLM: %w", err)
 	}
 	return res.GetAnswer(), nil
 }
 
-func PublishNotification(userID string, message string) error {
-	fmt.Printf("📢 Notification sent to %s: %s\n", userID, message)
+// NotificationSink adalah tujuan event node dari engine (Kafka, webhook, atau none).
+// Implementasi dipilih dari ENV NOTIFICATION_SINK lewat delivery.NotificationSinkFromEnv.
+type NotificationSink interface {
+	Publish(key []byte, payload []byte, headers map[string]string) error
+}
+
+// NoopSink membuang semua event (deployment tanpa Kafka / test lokal)
+type NoopSink struct{}
+
+func (NoopSink) Publish(key []byte, payload []byte, headers map[string]string) error {
 	return nil
 }
+
+var notificationSink NotificationSink = NoopSink{}
+
+// SetNotificationSink memasang sink event node; nil mengembalikan ke NoopSink
+func SetNotificationSink(s NotificationSink) {
+	if s == nil {
+		s = NoopSink{}
+	}
+	notificationSink = s
+}
+
+// PublishNotification mengirim event notifikasi ke sink; userID dipakai sebagai key (partisi Kafka),
+// headers (trace_id, tenant_id) ikut dikirim supaya consumer bisa korelasi log.
+func PublishNotification(userID string, message string, headers map[string]string) error {
+	return notificationSink.Publish([]byte(userID), []byte(message), headers)
+}
+
+// FlowCompletedTopic adalah topic event penyelesaian flow (ENV FLOW_COMPLETED_TOPIC, default flow-completed)
+func FlowCompletedTopic() string {
+	if topic := os.Getenv("FLOW_COMPLETED_TOPIC"); topic != "" {
+		return topic
+	}
+	return "flow-completed"
+}
+
+// PublishFlowCompleted mengirim satu event ringkasan per eksekusi flow; key = execution_id
+func PublishFlowCompleted(executionID string, payload []byte, headers map[string]string) error {
+	if kafkaWriter == nil {
+		return nil
+	}
+	return PublishKafkaMessage(context.Background(), FlowCompletedTopic(), []byte(executionID), payload, headers)
+}
+
+func toKafkaHeaders(headers map[string]string) []kafka.Header {
+	var out []kafka.Header
+	for k, v := range headers {
+		if v == "" {
+			continue
+		}
+		out = append(out, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return out
+}
@@ -1,10 +1,12 @@
 package observer
 
 import (
+	"context"
+
 	"github.com/milkyhoop/flow-executor/internal/ragclient"
 )
 
 // Actual RAG LLM query
-func QueryRAGLLM(query string, tenantID string) (string, error) {
-	return ragclient.QueryRAG(query, tenantID)
+func QueryRAGLLM(ctx context.Context, query string, tenantID string) (string, error) {
+	return ragclient.QueryRAG(ctx, query, tenantID)
 }
\ No newline at end of file
@@ -0,0 +1,57 @@
+package observer
+
+import (
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Nilai label tenant_id khusus untuk menjaga cardinality metric
+const (
+	tenantLabelDisabled = "all"     // METRICS_TENANT_LABEL=false
+	tenantLabelUnknown  = "unknown" // flow tanpa tenant_id
+	tenantLabelOther    = "other"   // tenant di luar batas METRICS_MAX_TENANTS
+)
+
+var (
+	tenantLabelOnce    sync.Once
+	tenantLabelEnabled bool
+	maxTenantLabels    int
+
+	tenantLabelMu   sync.Mutex
+	seenTenantLabel = map[string]bool{}
+)
+
+func loadTenantLabelConfig() {
+	tenantLabelEnabled = true
+	if v, err := strconv.ParseBool(os.Getenv("METRICS_TENANT_LABEL")); err == nil {
+		tenantLabelEnabled = v
+	}
+	maxTenantLabels = 100
+	if n, err := strconv.Atoi(os.Getenv("METRICS_MAX_TENANTS")); err == nil && n > 0 {
+		maxTenantLabels = n
+	}
+}
+
+// TenantLabel mengembalikan nilai label tenant_id untuk metric flow/node.
+// METRICS_TENANT_LABEL=false → selalu "all"; tenant ke-(METRICS_MAX_TENANTS+1) dst → "other".
+func TenantLabel(tenantID string) string {
+	tenantLabelOnce.Do(loadTenantLabelConfig)
+	if !tenantLabelEnabled {
+		return tenantLabelDisabled
+	}
+	if tenantID == "" {
+		return tenantLabelUnknown
+	}
+
+	tenantLabelMu.Lock()
+	defer tenantLabelMu.Unlock()
+	if seenTenantLabel[tenantID] {
+		return tenantID
+	}
+	if len(seenTenantLabel) >= maxTenantLabels {
+		return tenantLabelOther
+	}
+	seenTenantLabel[tenantID] = true
+	return tenantID
+}
@@ -132,6 +132,8 @@ type Flow struct {
 
 	Intent   []string  `protobuf:"bytes,1,rep,name=intent,proto3" json:"intent,omitempty"`
 	Entities *Entities `protobuf:"bytes,2,opt,name=entities,proto3" json:"entities,omitempty"`
+	// Versi skema flow, di-stamp oleh compiler (0 = file lama sebelum versioning)
+	SchemaVersion int32 `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
 }
 
 func (x *Flow) Reset() {
@@ -180,6 +182,13 @@ func (x *Flow) GetEntities() *Entities {
 	return nil
 }
 
+func (x *Flow) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
 type Entities struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -408,6 +417,204 @@ func (x *ProductServiceIssue) GetReason() string {
 	return ""
 }
 
+// Decompile .pb kembali ke JSON (untuk debugging artefak compile)
+type DecompileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PbPath     string `protobuf:"bytes,1,opt,name=pb_path,json=pbPath,proto3" json:"pb_path,omitempty"`
+	OutputPath string `protobuf:"bytes,2,opt,name=output_path,json=outputPath,proto3" json:"output_path,omitempty"`
+}
+
+func (x *DecompileRequest) Reset() {
+	*x = DecompileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_visualhoop_compiler_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecompileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecompileRequest) ProtoMessage() {}
+
+func (x *DecompileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_visualhoop_compiler_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecompileRequest.ProtoReflect.Descriptor instead.
+func (*DecompileRequest) Descriptor() ([]byte, []int) {
+	return file_visualhoop_compiler_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DecompileRequest) GetPbPath() string {
+	if x != nil {
+		return x.PbPath
+	}
+	return ""
+}
+
+func (x *DecompileRequest) GetOutputPath() string {
+	if x != nil {
+		return x.OutputPath
+	}
+	return ""
+}
+
+type DecompileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DecompileResponse) Reset() {
+	*x = DecompileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_visualhoop_compiler_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecompileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecompileResponse) ProtoMessage() {}
+
+func (x *DecompileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_visualhoop_compiler_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecompileResponse.ProtoReflect.Descriptor instead.
+func (*DecompileResponse) Descriptor() ([]byte, []int) {
+	return file_visualhoop_compiler_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DecompileResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Compile in-memory: JSON dikirim inline, .pb dikembalikan di response (tanpa disk I/O)
+type CompileBytesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Json []byte `protobuf:"bytes,1,opt,name=json,proto3" json:"json,omitempty"`
+}
+
+func (x *CompileBytesRequest) Reset() {
+	*x = CompileBytesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_visualhoop_compiler_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompileBytesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileBytesRequest) ProtoMessage() {}
+
+func (x *CompileBytesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_visualhoop_compiler_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileBytesRequest.ProtoReflect.Descriptor instead.
+func (*CompileBytesRequest) Descriptor() ([]byte, []int) {
+	return file_visualhoop_compiler_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CompileBytesRequest) GetJson() []byte {
+	if x != nil {
+		return x.Json
+	}
+	return nil
+}
+
+type CompileBytesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pb []byte `protobuf:"bytes,1,opt,name=pb,proto3" json:"pb,omitempty"`
+}
+
+func (x *CompileBytesResponse) Reset() {
+	*x = CompileBytesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_visualhoop_compiler_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompileBytesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompileBytesResponse) ProtoMessage() {}
+
+func (x *CompileBytesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_visualhoop_compiler_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompileBytesResponse.ProtoReflect.Descriptor instead.
+func (*CompileBytesResponse) Descriptor() ([]byte, []int) {
+	return file_visualhoop_compiler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CompileBytesResponse) GetPb() []byte {
+	if x != nil {
+		return x.Pb
+	}
+	return nil
+}
+
 var File_visualhoop_compiler_proto protoreflect.FileDescriptor
 
 var file_visualhoop_compiler_proto_rawDesc = []byte{
@@ -423,60 +630,88 @@ var file_visualhoop_compiler_proto_rawDesc = []byte{
 	0x09, 0x52, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x22, 0x2b, 0x0a,
 	0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x59, 0x0a, 0x04, 0x46, 0x6c,
-	0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x65, 0x6e,
-	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76,
-	0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
-	0x65, 0x72, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x08, 0x65, 0x6e, 0x74,
-	0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xf7, 0x01, 0x0a, 0x08, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69,
-	0x65, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f,
-	0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f,
-	0x6d, 0x65, 0x72, 0x52, 0x08, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x52, 0x0a,
-	0x11, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61,
-	0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x4f,
-	0x72, 0x64, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x10, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x5c, 0x0a, 0x15, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x28, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f,
-	0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65, 0x52, 0x13, 0x70, 0x72, 0x6f, 0x64,
-	0x75, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65, 0x22,
-	0x4b, 0x0a, 0x08, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63,
-	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x10,
-	0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x69,
-	0x74, 0x65, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
-	0x69, 0x74, 0x65, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e,
-	0x74, 0x69, 0x74, 0x79, 0x22, 0x2d, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x32, 0xb1, 0x01, 0x0a, 0x12, 0x56, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f,
-	0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x12, 0x5c, 0x0a, 0x0f, 0x43, 0x6f,
-	0x6d, 0x70, 0x69, 0x6c, 0x65, 0x4a, 0x73, 0x6f, 0x6e, 0x54, 0x6f, 0x50, 0x62, 0x12, 0x23, 0x2e,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x04, 0x46,
+	0x6c, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x08, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
 	0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69,
-	0x6c, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f,
-	0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6c, 0x6b, 0x79, 0x68, 0x6f, 0x6f, 0x70, 0x2f,
-	0x6d, 0x69, 0x6c, 0x6b, 0x79, 0x68, 0x6f, 0x6f, 0x70, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e,
-	0x64, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x76, 0x69, 0x73, 0x75, 0x61,
-	0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x2d, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2f, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x08, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xf7, 0x01,
+	0x0a, 0x08, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x63, 0x75,
+	0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x76,
+	0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x72, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x52, 0x08, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x52, 0x0a, 0x11, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f,
+	0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5c, 0x0a, 0x15, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61,
+	0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x73, 0x73,
+	0x75, 0x65, 0x52, 0x13, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65, 0x22, 0x4b, 0x0a, 0x08, 0x43, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x10, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x69, 0x74, 0x65, 0x6d, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x2d, 0x0a, 0x13,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x4c, 0x0a, 0x10, 0x44,
+	0x65, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x70, 0x62, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x62, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x22, 0x2d, 0x0a, 0x11, 0x44, 0x65, 0x63,
+	0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x29, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70,
+	0x69, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x6a,
+	0x73, 0x6f, 0x6e, 0x22, 0x26, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x70,
+	0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x70, 0x62, 0x32, 0xfa, 0x02, 0x0a, 0x12,
+	0x56, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x72, 0x12, 0x5c, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x4a, 0x73, 0x6f,
+	0x6e, 0x54, 0x6f, 0x50, 0x62, 0x12, 0x23, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f,
+	0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x76, 0x69, 0x73,
+	0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x62, 0x0a, 0x11, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x50, 0x62, 0x54,
+	0x6f, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f,
+	0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x6f,
+	0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x76,
+	0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f,
+	0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69,
+	0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29,
+	0x2e, 0x76, 0x69, 0x73, 0x75, 0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70,
+	0x69, 0x6c, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0b, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x69, 0x6c, 0x6b, 0x79, 0x68, 0x6f, 0x6f, 0x70,
+	0x2f, 0x6d, 0x69, 0x6c, 0x6b, 0x79, 0x68, 0x6f, 0x6f, 0x70, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2f, 0x76, 0x69, 0x73, 0x75,
+	0x61, 0x6c, 0x68, 0x6f, 0x6f, 0x70, 0x2d, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x72, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -491,31 +726,39 @@ func file_visualhoop_compiler_proto_rawDescGZIP() []byte {
 	return file_visualhoop_compiler_proto_rawDescData
 }
 
-var file_visualhoop_compiler_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_visualhoop_compiler_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_visualhoop_compiler_proto_goTypes = []interface{}{
-	(*CompileRequest)(nil),      // 0: visualhoop_compiler.CompileRequest
-	(*CompileResponse)(nil),     // 1: visualhoop_compiler.CompileResponse
-	(*Flow)(nil),                // 2: visualhoop_compiler.Flow
-	(*Entities)(nil),            // 3: visualhoop_compiler.Entities
-	(*Customer)(nil),            // 4: visualhoop_compiler.Customer
-	(*OrderTransaction)(nil),    // 5: visualhoop_compiler.OrderTransaction
-	(*ProductServiceIssue)(nil), // 6: visualhoop_compiler.ProductServiceIssue
-	(*empty.Empty)(nil),         // 7: google.protobuf.Empty
+	(*CompileRequest)(nil),       // 0: visualhoop_compiler.CompileRequest
+	(*CompileResponse)(nil),      // 1: visualhoop_compiler.CompileResponse
+	(*Flow)(nil),                 // 2: visualhoop_compiler.Flow
+	(*Entities)(nil),             // 3: visualhoop_compiler.Entities
+	(*Customer)(nil),             // 4: visualhoop_compiler.Customer
+	(*OrderTransaction)(nil),     // 5: visualhoop_compiler.OrderTransaction
+	(*ProductServiceIssue)(nil),  // 6: visualhoop_compiler.ProductServiceIssue
+	(*DecompileRequest)(nil),     // 7: visualhoop_compiler.DecompileRequest
+	(*DecompileResponse)(nil),    // 8: visualhoop_compiler.DecompileResponse
+	(*CompileBytesRequest)(nil),  // 9: visualhoop_compiler.CompileBytesRequest
+	(*CompileBytesResponse)(nil), // 10: visualhoop_compiler.CompileBytesResponse
+	(*empty.Empty)(nil),          // 11: google.protobuf.Empty
 }
 var file_visualhoop_compiler_proto_depIdxs = []int32{
-	3, // 0: visualhoop_compiler.Flow.entities:type_name -> visualhoop_compiler.Entities
-	4, // 1: visualhoop_compiler.Entities.customer:type_name -> visualhoop_compiler.Customer
-	5, // 2: visualhoop_compiler.Entities.order_transaction:type_name -> visualhoop_compiler.OrderTransaction
-	6, // 3: visualhoop_compiler.Entities.product_service_issue:type_name -> visualhoop_compiler.ProductServiceIssue
-	0, // 4: visualhoop_compiler.VisualhoopCompiler.CompileJsonToPb:input_type -> visualhoop_compiler.CompileRequest
-	7, // 5: visualhoop_compiler.VisualhoopCompiler.HealthCheck:input_type -> google.protobuf.Empty
-	1, // 6: visualhoop_compiler.VisualhoopCompiler.CompileJsonToPb:output_type -> visualhoop_compiler.CompileResponse
-	7, // 7: visualhoop_compiler.VisualhoopCompiler.HealthCheck:output_type -> google.protobuf.Empty
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3,  // 0: visualhoop_compiler.Flow.entities:type_name -> visualhoop_compiler.Entities
+	4,  // 1: visualhoop_compiler.Entities.customer:type_name -> visualhoop_compiler.Customer
+	5,  // 2: visualhoop_compiler.Entities.order_transaction:type_name -> visualhoop_compiler.OrderTransaction
+	6,  // 3: visualhoop_compiler.Entities.product_service_issue:type_name -> visualhoop_compiler.ProductServiceIssue
+	0,  // 4: visualhoop_compiler.VisualhoopCompiler.CompileJsonToPb:input_type -> visualhoop_compiler.CompileRequest
+	7,  // 5: visualhoop_compiler.VisualhoopCompiler.DecompilePbToJson:input_type -> visualhoop_compiler.DecompileRequest
+	9,  // 6: visualhoop_compiler.VisualhoopCompiler.CompileBytes:input_type -> visualhoop_compiler.CompileBytesRequest
+	11, // 7: visualhoop_compiler.VisualhoopCompiler.HealthCheck:input_type -> google.protobuf.Empty
+	1,  // 8: visualhoop_compiler.VisualhoopCompiler.CompileJsonToPb:output_type -> visualhoop_compiler.CompileResponse
+	8,  // 9: visualhoop_compiler.VisualhoopCompiler.DecompilePbToJson:output_type -> visualhoop_compiler.DecompileResponse
+	10, // 10: visualhoop_compiler.VisualhoopCompiler.CompileBytes:output_type -> visualhoop_compiler.CompileBytesResponse
+	11, // 11: visualhoop_compiler.VisualhoopCompiler.HealthCheck:output_type -> google.protobuf.Empty
+	8,  // [8:12] is the sub-list for method output_type
+	4,  // [4:8] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_visualhoop_compiler_proto_init() }
@@ -608,6 +851,54 @@ func file_visualhoop_compiler_proto_init() {
 				return nil
 			}
 		}
+		file_visualhoop_compiler_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecompileRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_visualhoop_compiler_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecompileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_visualhoop_compiler_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompileBytesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_visualhoop_compiler_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompileBytesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -615,7 +906,7 @@ func file_visualhoop_compiler_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_visualhoop_compiler_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,8 +20,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	VisualhoopCompiler_CompileJsonToPb_FullMethodName = "/visualhoop_compiler.VisualhoopCompiler/CompileJsonToPb"
-	VisualhoopCompiler_HealthCheck_FullMethodName     = "/visualhoop_compiler.VisualhoopCompiler/HealthCheck"
+	VisualhoopCompiler_CompileJsonToPb_FullMethodName   = "/visualhoop_compiler.VisualhoopCompiler/CompileJsonToPb"
+	VisualhoopCompiler_DecompilePbToJson_FullMethodName = "/visualhoop_compiler.VisualhoopCompiler/DecompilePbToJson"
+	VisualhoopCompiler_CompileBytes_FullMethodName      = "/visualhoop_compiler.VisualhoopCompiler/CompileBytes"
+	VisualhoopCompiler_HealthCheck_FullMethodName       = "/visualhoop_compiler.VisualhoopCompiler/HealthCheck"
 )
 
 // VisualhoopCompilerClient is the client API for VisualhoopCompiler service.
@@ -29,6 +31,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VisualhoopCompilerClient interface {
 	CompileJsonToPb(ctx context.Context, in *CompileRequest, opts ...grpc.CallOption) (*CompileResponse, error)
+	DecompilePbToJson(ctx context.Context, in *DecompileRequest, opts ...grpc.CallOption) (*DecompileResponse, error)
+	CompileBytes(ctx context.Context, in *CompileBytesRequest, opts ...grpc.CallOption) (*CompileBytesResponse, error)
 	HealthCheck(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
@@ -49,6 +53,24 @@ func (c *visualhoopCompilerClient) CompileJsonToPb(ctx context.Context, in *Comp
 	return out, nil
 }
 
+func (c *visualhoopCompilerClient) DecompilePbToJson(ctx context.Context, in *DecompileRequest, opts ...grpc.CallOption) (*DecompileResponse, error) {
+	out := new(DecompileResponse)
+	err := c.cc.Invoke(ctx, VisualhoopCompiler_DecompilePbToJson_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *visualhoopCompilerClient) CompileBytes(ctx context.Context, in *CompileBytesRequest, opts ...grpc.CallOption) (*CompileBytesResponse, error) {
+	out := new(CompileBytesResponse)
+	err := c.cc.Invoke(ctx, VisualhoopCompiler_CompileBytes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *visualhoopCompilerClient) HealthCheck(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, VisualhoopCompiler_HealthCheck_FullMethodName, in, out, opts...)
@@ -63,6 +85,8 @@ func (c *visualhoopCompilerClient) HealthCheck(ctx context.Context, in *empty.Em
 // for forward compatibility
 type VisualhoopCompilerServer interface {
 	CompileJsonToPb(context.Context, *CompileRequest) (*CompileResponse, error)
+	DecompilePbToJson(context.Context, *DecompileRequest) (*DecompileResponse, error)
+	CompileBytes(context.Context, *CompileBytesRequest) (*CompileBytesResponse, error)
 	HealthCheck(context.Context, *empty.Empty) (*empty.Empty, error)
 	mustEmbedUnimplementedVisualhoopCompilerServer()
 }
@@ -74,6 +98,12 @@ type UnimplementedVisualhoopCompilerServer struct {
 func (UnimplementedVisualhoopCompilerServer) CompileJsonToPb(context.Context, *CompileRequest) (*CompileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CompileJsonToPb not implemented")
 }
+func (UnimplementedVisualhoopCompilerServer) DecompilePbToJson(context.Context, *DecompileRequest) (*DecompileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecompilePbToJson not implemented")
+}
+func (UnimplementedVisualhoopCompilerServer) CompileBytes(context.Context, *CompileBytesRequest) (*CompileBytesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompileBytes not implemented")
+}
 func (UnimplementedVisualhoopCompilerServer) HealthCheck(context.Context, *empty.Empty) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HealthCheck not implemented")
 }
@@ -108,6 +138,42 @@ func _VisualhoopCompiler_CompileJsonToPb_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VisualhoopCompiler_DecompilePbToJson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecompileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisualhoopCompilerServer).DecompilePbToJson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisualhoopCompiler_DecompilePbToJson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisualhoopCompilerServer).DecompilePbToJson(ctx, req.(*DecompileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VisualhoopCompiler_CompileBytes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompileBytesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisualhoopCompilerServer).CompileBytes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VisualhoopCompiler_CompileBytes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisualhoopCompilerServer).CompileBytes(ctx, req.(*CompileBytesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VisualhoopCompiler_HealthCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
@@ -137,6 +203,14 @@ var VisualhoopCompiler_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CompileJsonToPb",
 			Handler:    _VisualhoopCompiler_CompileJsonToPb_Handler,
 		},
+		{
+			MethodName: "DecompilePbToJson",
+			Handler:    _VisualhoopCompiler_DecompilePbToJson_Handler,
+		},
+		{
+			MethodName: "CompileBytes",
+			Handler:    _VisualhoopCompiler_CompileBytes_Handler,
+		},
 		{
 			MethodName: "HealthCheck",
 			Handler:    _VisualhoopCompiler_HealthCheck_Handler,
@@ -0,0 +1,162 @@
+package ragclient
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// ErrRAGUnavailable dikembalikan saat circuit breaker terbuka (fast-fail, tanpa gRPC call)
+// atau saat koneksi ke service RAG tidak bisa dibuat.
+var ErrRAGUnavailable = errors.New("RAG temporarily unavailable")
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker membuka sirkuit setelah N kegagalan berturut-turut,
+// fast-fail selama cooldown, lalu half-open untuk satu probe request.
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker membuat breaker dengan threshold & cooldown dari env
+// (RAG_BREAKER_FAILURE_THRESHOLD, RAG_BREAKER_COOLDOWN).
+func NewCircuitBreaker(name string) *CircuitBreaker {
+	threshold := 5
+	if v := os.Getenv("RAG_BREAKER_FAILURE_THRESHOLD"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			threshold = n
+		}
+	}
+
+	cooldown := 30 * time.Second
+	if v := os.Getenv("RAG_BREAKER_COOLDOWN"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cooldown = d
+		}
+	}
+
+	cb := &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+	RagBreakerState.WithLabelValues(name).Set(breakerClosed)
+	return cb
+}
+
+// Execute menjalankan fn jika sirkuit mengizinkan dan mencatat hasilnya.
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	if !cb.allow() {
+		return ErrRAGUnavailable
+	}
+	err := fn()
+	cb.record(err)
+	return err
+}
+
+func (cb *CircuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case breakerOpen:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			return false
+		}
+		cb.setState(breakerHalfOpen)
+		cb.probing = true
+		return true
+	case breakerHalfOpen:
+		// Hanya satu probe yang boleh lewat saat half-open
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// Hasil call dari sisi kesehatan upstream
+const (
+	outcomeHealthy = iota
+	outcomeFailure
+	outcomeNeutral
+)
+
+// classifyOutcome: hanya Unavailable, DeadlineExceeded dan ResourceExhausted yang menandakan
+// upstream bermasalah. Error aplikasi (InvalidArgument, NotFound, ...) berarti upstream sehat;
+// cancel dari caller tidak mengatakan apa-apa tentang upstream.
+func classifyOutcome(err error) int {
+	if err == nil {
+		return outcomeHealthy
+	}
+	if errors.Is(err, context.Canceled) {
+		return outcomeNeutral
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return outcomeFailure
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return outcomeFailure
+	case codes.Canceled:
+		return outcomeNeutral
+	default:
+		return outcomeHealthy
+	}
+}
+
+func (cb *CircuitBreaker) record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.probing = false
+	outcome := classifyOutcome(err)
+	if outcome == outcomeNeutral {
+		return
+	}
+	if outcome == outcomeHealthy {
+		cb.failures = 0
+		if cb.state != breakerClosed {
+			utils.Component("ragclient").Info().Str("breaker", cb.name).Msg("✅ Circuit breaker closed")
+			cb.setState(breakerClosed)
+		}
+		return
+	}
+
+	cb.failures++
+	if cb.state == breakerHalfOpen || cb.failures >= cb.threshold {
+		if cb.state != breakerOpen {
+			utils.Component("ragclient").Warn().Str("breaker", cb.name).Int("failures", cb.failures).Msg("🚨 Circuit breaker opened")
+		}
+		cb.openedAt = time.Now()
+		cb.setState(breakerOpen)
+	}
+}
+
+func (cb *CircuitBreaker) setState(state int) {
+	cb.state = state
+	RagBreakerState.WithLabelValues(cb.name).Set(float64(state))
+}
@@ -0,0 +1,114 @@
+package ragclient
+
+import (
+	"container/list"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// faqCache adalah LRU cache sederhana untuk hasil QueryRAG (rag_search_faq).
+// Key = tenant_id + query yang sudah dinormalisasi.
+type faqCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	ll      *list.List
+	items   map[string]*list.Element
+}
+
+type faqCacheEntry struct {
+	key       string
+	answer    string
+	expiresAt time.Time
+}
+
+var (
+	queryCache     *faqCache
+	queryCacheOnce sync.Once
+)
+
+// getQueryCache mengembalikan cache FAQ, atau nil jika RAG_CACHE_ENABLED tidak aktif.
+func getQueryCache() *faqCache {
+	queryCacheOnce.Do(func() {
+		enabled, _ := strconv.ParseBool(os.Getenv("RAG_CACHE_ENABLED"))
+		if !enabled {
+			return
+		}
+
+		ttl := 5 * time.Minute
+		if v := os.Getenv("RAG_CACHE_TTL"); v != "" {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				ttl = d
+			}
+		}
+
+		maxSize := 1000
+		if v := os.Getenv("RAG_CACHE_MAX_SIZE"); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				maxSize = n
+			}
+		}
+
+		queryCache = newFAQCache(ttl, maxSize)
+	})
+	return queryCache
+}
+
+func newFAQCache(ttl time.Duration, maxSize int) *faqCache {
+	return &faqCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		ll:      list.New(),
+		items:   make(map[string]*list.Element),
+	}
+}
+
+// cacheKey menormalisasi query (lowercase, whitespace dirapikan) supaya variasi kecil tetap hit.
+func cacheKey(tenantID, query string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	return tenantID + "\x00" + normalized
+}
+
+func (c *faqCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	entry := el.Value.(*faqCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return "", false
+	}
+	c.ll.MoveToFront(el)
+	return entry.answer, true
+}
+
+func (c *faqCache) Set(key, answer string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*faqCacheEntry)
+		entry.answer = answer
+		entry.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&faqCacheEntry{key: key, answer: answer, expiresAt: expiresAt})
+	c.items[key] = el
+
+	for c.ll.Len() > c.maxSize {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*faqCacheEntry).key)
+	}
+}
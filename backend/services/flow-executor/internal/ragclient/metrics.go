@@ -0,0 +1,101 @@
+package ragclient
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	RagCacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "rag_cache_hits_total",
+			Help: "Total number of rag_search_faq queries served from cache",
+		},
+	)
+
+	RagCacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "rag_cache_misses_total",
+			Help: "Total number of rag_search_faq queries that missed the cache",
+		},
+	)
+
+	RagBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rag_circuit_breaker_state",
+			Help: "RAG circuit breaker state (0=closed, 1=open, 2=half-open)",
+		},
+		[]string{"backend"},
+	)
+
+	RagPoolSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rag_pool_size",
+			Help: "Number of gRPC connections in the RAG client pool",
+		},
+		[]string{"backend"},
+	)
+
+	// RagPoolInFlight / RagPoolSize = utilisasi pool; in-flight yang timpang antar conn berarti round-robin tidak merata
+	RagPoolInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "rag_pool_in_flight",
+			Help: "Number of RAG gRPC calls currently in flight per pooled connection",
+		},
+		[]string{"backend", "conn"},
+	)
+
+	RagRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "rag_request_duration_seconds",
+			Help:    "Duration of RAG gRPC calls in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "status"},
+	)
+
+	RagRequestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rag_request_errors_total",
+			Help: "Total number of failed RAG gRPC calls by status code",
+		},
+		[]string{"method", "code"},
+	)
+)
+
+// RegisterMetricsWith mendaftarkan semua metric RAG (cache, breaker, pool, latency, error) ke reg.
+// Metric yang sudah terdaftar di reg dilewati, jadi aman dipanggil berkali-kali.
+func RegisterMetricsWith(reg prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		RagCacheHits,
+		RagCacheMisses,
+		RagBreakerState,
+		RagPoolSize,
+		RagPoolInFlight,
+		RagRequestDuration,
+		RagRequestErrors,
+	}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ObserveCall menjalankan satu gRPC call RAG sambil mencatat durasi dan error (label = gRPC status code).
+func ObserveCall(method string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+
+	result := "success"
+	if err != nil {
+		result = "error"
+		RagRequestErrors.WithLabelValues(method, status.Code(err).String()).Inc()
+	}
+	RagRequestDuration.WithLabelValues(method, result).Observe(time.Since(start).Seconds())
+	return err
+}
@@ -0,0 +1,158 @@
+// Package mock menyediakan RAG CRUD & RAG LLM gRPC server in-memory untuk test flow
+// yang memakai node rag_* tanpa backend RAG asli.
+package mock
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	pb "github.com/milkyhoop/flow-executor/internal/proto"
+	ragcrud_pb "github.com/milkyhoop/flow-executor/internal/proto/ragcrud"
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+)
+
+const bufSize = 1 << 20
+
+// CrudServer adalah RagCrudServiceServer in-memory; dokumen di-set per tenant lewat AddDocument.
+// FuzzySearchDocuments mengembalikan dokumen tenant yang content/title-nya mengandung query.
+type CrudServer struct {
+	ragcrud_pb.UnimplementedRagCrudServiceServer
+
+	mu     sync.Mutex
+	nextID int32
+	docs   map[string][]*ragcrud_pb.RagDocumentResponse
+	calls  []*ragcrud_pb.FuzzySearchRequest
+
+	// SearchFunc meng-override hasil FuzzySearchDocuments (mis. untuk simulasi error)
+	SearchFunc func(ctx context.Context, req *ragcrud_pb.FuzzySearchRequest) (*ragcrud_pb.FuzzySearchResponse, error)
+}
+
+// NewCrudServer membuat CrudServer kosong
+func NewCrudServer() *CrudServer {
+	return &CrudServer{docs: map[string][]*ragcrud_pb.RagDocumentResponse{}}
+}
+
+// AddDocument menambah dokumen ke tenant dan mengembalikan id-nya
+func (s *CrudServer) AddDocument(tenantID, title, content string) int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	s.docs[tenantID] = append(s.docs[tenantID], &ragcrud_pb.RagDocumentResponse{Id: s.nextID, Title: title, Content: content})
+	return s.nextID
+}
+
+// SearchCalls mengembalikan semua request FuzzySearchDocuments yang diterima
+func (s *CrudServer) SearchCalls() []*ragcrud_pb.FuzzySearchRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*ragcrud_pb.FuzzySearchRequest(nil), s.calls...)
+}
+
+func (s *CrudServer) FuzzySearchDocuments(ctx context.Context, req *ragcrud_pb.FuzzySearchRequest) (*ragcrud_pb.FuzzySearchResponse, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, req)
+	search := s.SearchFunc
+	s.mu.Unlock()
+
+	if search != nil {
+		return search(ctx, req)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := strings.ToLower(req.GetSearchContent())
+	resp := &ragcrud_pb.FuzzySearchResponse{}
+	for _, doc := range s.docs[req.GetTenantId()] {
+		if strings.Contains(strings.ToLower(doc.Content), query) || strings.Contains(strings.ToLower(doc.Title), query) {
+			resp.Documents = append(resp.Documents, doc)
+		}
+	}
+	return resp, nil
+}
+
+func (s *CrudServer) CreateRagDocument(ctx context.Context, req *ragcrud_pb.CreateRagDocumentRequest) (*ragcrud_pb.RagDocumentResponse, error) {
+	id := s.AddDocument(req.GetTenantId(), req.GetTitle(), req.GetContent())
+	return &ragcrud_pb.RagDocumentResponse{Id: id, Title: req.GetTitle(), Content: req.GetContent()}, nil
+}
+
+// LLMServer adalah RagLlmServiceServer in-memory dengan jawaban yang bisa diprogram per pertanyaan
+type LLMServer struct {
+	pb.UnimplementedRagLlmServiceServer
+
+	mu      sync.Mutex
+	answers map[string]string
+
+	// Default dipakai jika pertanyaan tidak punya jawaban terprogram
+	Default string
+}
+
+// NewLLMServer membuat LLMServer tanpa jawaban terprogram
+func NewLLMServer() *LLMServer {
+	return &LLMServer{answers: map[string]string{}}
+}
+
+// SetAnswer memprogram jawaban untuk pertanyaan tertentu
+func (s *LLMServer) SetAnswer(question, answer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.answers[question] = answer
+}
+
+func (s *LLMServer) GenerateAnswer(ctx context.Context, req *pb.GenerateAnswerRequest) (*pb.GenerateAnswerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if answer, ok := s.answers[req.GetQuestion()]; ok {
+		return &pb.GenerateAnswerResponse{Answer: answer}, nil
+	}
+	return &pb.GenerateAnswerResponse{Answer: s.Default}, nil
+}
+
+// Harness adalah mock RAG CRUD + LLM yang berjalan di bufconn
+type Harness struct {
+	Crud *CrudServer
+	LLM  *LLMServer
+
+	server *grpc.Server
+	conn   *grpc.ClientConn
+}
+
+// Start menjalankan mock server di bufconn dan mengarahkan ragclient & observer ke sana.
+// Panggil Close setelah selesai (biasanya lewat t.Cleanup).
+func Start() (*Harness, error) {
+	h := &Harness{Crud: NewCrudServer(), LLM: NewLLMServer()}
+
+	lis := bufconn.Listen(bufSize)
+	h.server = grpc.NewServer()
+	ragcrud_pb.RegisterRagCrudServiceServer(h.server, h.Crud)
+	pb.RegisterRagLlmServiceServer(h.server, h.LLM)
+	go h.server.Serve(lis)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		h.server.Stop()
+		return nil, err
+	}
+	h.conn = conn
+
+	ragclient.SetRagCrudClient(ragcrud_pb.NewRagCrudServiceClient(conn))
+	observer.SetRagLLMClient(pb.NewRagLlmServiceClient(conn))
+	return h, nil
+}
+
+// Close menutup koneksi client dan menghentikan server
+func (h *Harness) Close() {
+	h.conn.Close()
+	h.server.Stop()
+}
@@ -0,0 +1,40 @@
+package ragclient
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+)
+
+// ragCrudPoolSize dari ENV RAGCRUD_POOL_SIZE (default 4). Satu koneksi HTTP/2 bisa macet saat
+// banyak flow paralel mengirim message besar, jadi traffic dibagi round-robin ke beberapa koneksi.
+func ragCrudPoolSize() int {
+	if v := os.Getenv("RAGCRUD_POOL_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 4
+}
+
+// roundRobin memilih index koneksi berikutnya dari pool berukuran n
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+func (r *roundRobin) pick(n int) int {
+	return int((r.next.Add(1) - 1) % uint64(n))
+}
+
+// inFlightInterceptor mencatat call yang sedang berjalan per koneksi pool ke rag_pool_in_flight
+func inFlightInterceptor(backend, conn string) grpc.UnaryClientInterceptor {
+	gauge := RagPoolInFlight.WithLabelValues(backend, conn)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		gauge.Inc()
+		defer gauge.Dec()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -3,48 +3,91 @@ package ragclient
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc"
 	ragcrud_pb "github.com/milkyhoop/flow-executor/internal/proto/ragcrud"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
 var (
-	ragCrudClient   ragcrud_pb.RagCrudServiceClient
+	ragCrudClients  []ragcrud_pb.RagCrudServiceClient
+	ragCrudRR       roundRobin
 	ragCrudConnOnce sync.Once
+
+	ragCrudBreaker     *CircuitBreaker
+	ragCrudBreakerOnce sync.Once
 )
 
+func getRagCrudBreaker() *CircuitBreaker {
+	ragCrudBreakerOnce.Do(func() {
+		ragCrudBreaker = NewCircuitBreaker("ragcrud")
+	})
+	return ragCrudBreaker
+}
+
+// RagCrudTarget mengembalikan alamat RAG CRUD service dari ENV (dipakai juga oleh /readyz)
+func RagCrudTarget() string {
+	ragCrudHost := os.Getenv("RAGCRUD_GRPC_HOST")
+	ragCrudPort := os.Getenv("RAGCRUD_GRPC_PORT")
+	if ragCrudHost == "" {
+		ragCrudHost = "ragcrud_service"
+	}
+	if ragCrudPort == "" {
+		ragCrudPort = "5001"
+	}
+	return fmt.Sprintf("%s:%s", ragCrudHost, ragCrudPort)
+}
+
+// getRagCrudClient mengembalikan client berikutnya (round-robin) dari pool RAGCRUD_POOL_SIZE koneksi
 func getRagCrudClient() ragcrud_pb.RagCrudServiceClient {
 	ragCrudConnOnce.Do(func() {
-		ragCrudHost := os.Getenv("RAGCRUD_GRPC_HOST")
-		ragCrudPort := os.Getenv("RAGCRUD_GRPC_PORT")
-		if ragCrudHost == "" {
-			ragCrudHost = "ragcrud_service"
-		}
-		if ragCrudPort == "" {
-			ragCrudPort = "5001"
-		}
-		ragCrudAddr := fmt.Sprintf("%s:%s", ragCrudHost, ragCrudPort)
-			
+		ragCrudAddr := RagCrudTarget()
+		size := ragCrudPoolSize()
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		conn, err := grpc.DialContext(ctx, ragCrudAddr, grpc.WithInsecure(), grpc.WithBlock())
-		if err != nil {
-			log.Fatalf("❌ Gagal konek ke RAG CRUD service: %v", err)
+		for i := 0; i < size; i++ {
+			conn, err := grpc.DialContext(ctx, ragCrudAddr, grpc.WithInsecure(), grpc.WithBlock(),
+				grpc.WithUnaryInterceptor(inFlightInterceptor("ragcrud", strconv.Itoa(i))), tracing.DialOption())
+			if err != nil {
+				utils.Component("ragclient").Fatal().Err(err).Str("addr", ragCrudAddr).Msg("❌ Gagal konek ke RAG CRUD service")
+			}
+			ragCrudClients = append(ragCrudClients, ragcrud_pb.NewRagCrudServiceClient(conn))
 		}
-
-		ragCrudClient = ragcrud_pb.NewRagCrudServiceClient(conn)
+		RagPoolSize.WithLabelValues("ragcrud").Set(float64(size))
+		utils.Component("ragclient").Info().Str("addr", ragCrudAddr).Int("pool_size", size).Msg("🔗 Pool koneksi RAG CRUD siap")
 	})
-	return ragCrudClient
+	return ragCrudClients[ragCrudRR.pick(len(ragCrudClients))]
+}
+
+// SetRagCrudClient mengganti client RAG CRUD (mis. mock di test); dial lazy ke RagCrudTarget dilewati
+func SetRagCrudClient(c ragcrud_pb.RagCrudServiceClient) {
+	ragCrudConnOnce.Do(func() {})
+	ragCrudClients = []ragcrud_pb.RagCrudServiceClient{c}
+}
+
+// ragCrudFor memilih client & breaker RAG CRUD per tenant: endpoint tenant dari pool jika
+// di-mapping (LoadTenantRoutes), selain itu endpoint default
+func ragCrudFor(tenantID string) (ragcrud_pb.RagCrudServiceClient, *CircuitBreaker, error) {
+	if addr := TenantRagCrudTarget(tenantID); addr != "" {
+		conn, err := PooledConn(addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ragcrud_pb.NewRagCrudServiceClient(conn), BreakerFor("ragcrud", addr), nil
+	}
+	return getRagCrudClient(), getRagCrudBreaker(), nil
 }
 
-func UpdateRagDocument(id int32, title, content string) (*ragcrud_pb.RagDocumentResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func UpdateRagDocument(ctx context.Context, id int32, title, content string) (*ragcrud_pb.RagDocumentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req := &ragcrud_pb.UpdateRagDocumentRequest{
@@ -53,7 +96,14 @@ func UpdateRagDocument(id int32, title, content string) (*ragcrud_pb.RagDocument
 		Content: content,
 	}
 
-	resp, err := getRagCrudClient().UpdateRagDocument(ctx, req)
+	var resp *ragcrud_pb.RagDocumentResponse
+	err := getRagCrudBreaker().Execute(func() error {
+		return ObserveCall("UpdateRagDocument", func() error {
+			var callErr error
+			resp, callErr = getRagCrudClient().UpdateRagDocument(ctx, req)
+			return callErr
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("❌ Gagal update RAG document: %w", err)
 	}
@@ -61,15 +111,22 @@ func UpdateRagDocument(id int32, title, content string) (*ragcrud_pb.RagDocument
 	return resp, nil
 }
 
-func DeleteRagDocument(id int32) (*ragcrud_pb.RagDocumentResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func DeleteRagDocument(ctx context.Context, id int32) (*ragcrud_pb.RagDocumentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req := &ragcrud_pb.DeleteRagDocumentRequest{
 		Id: id,
 	}
 
-	resp, err := getRagCrudClient().DeleteRagDocument(ctx, req)
+	var resp *ragcrud_pb.RagDocumentResponse
+	err := getRagCrudBreaker().Execute(func() error {
+		return ObserveCall("DeleteRagDocument", func() error {
+			var callErr error
+			resp, callErr = getRagCrudClient().DeleteRagDocument(ctx, req)
+			return callErr
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("❌ Gagal delete RAG document: %w", err)
 	}
@@ -77,8 +134,8 @@ func DeleteRagDocument(id int32) (*ragcrud_pb.RagDocumentResponse, error) {
 	return resp, nil
 }
 
-func UpdateRAGDocument(id int32, title, content string) (string, error) {
-	resp, err := UpdateRagDocument(id, title, content)
+func UpdateRAGDocument(ctx context.Context, id int32, title, content string) (string, error) {
+	resp, err := UpdateRagDocument(ctx, id, title, content)
 	if err != nil {
 		return "", err
 	}
@@ -86,8 +143,8 @@ func UpdateRAGDocument(id int32, title, content string) (string, error) {
 	return fmt.Sprintf("✅ Document ID %d berhasil diupdate: %s", resp.Id, resp.Title), nil
 }
 
-func DeleteRAGDocument(id int32) (string, error) {
-	resp, err := DeleteRagDocument(id)
+func DeleteRAGDocument(ctx context.Context, id int32) (string, error) {
+	resp, err := DeleteRagDocument(ctx, id)
 	if err != nil {
 		return "", err
 	}
@@ -95,8 +152,8 @@ func DeleteRAGDocument(id int32) (string, error) {
 	return fmt.Sprintf("✅ Document ID %d berhasil dihapus: %s", resp.Id, resp.Title), nil
 }
 
-func UpdateRagDocumentBySearch(tenantID, searchContent, newContent string) (*ragcrud_pb.RagDocumentResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func UpdateRagDocumentBySearch(ctx context.Context, tenantID, searchContent, newContent string) (*ragcrud_pb.RagDocumentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	req := &ragcrud_pb.UpdateRagDocumentBySearchRequest{
@@ -105,7 +162,19 @@ func UpdateRagDocumentBySearch(tenantID, searchContent, newContent string) (*rag
 		NewContent:    newContent,
 	}
 
-	resp, err := getRagCrudClient().UpdateRagDocumentBySearch(ctx, req)
+	client, breaker, err := ragCrudFor(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *ragcrud_pb.RagDocumentResponse
+	err = breaker.Execute(func() error {
+		return ObserveCall("UpdateRagDocumentBySearch", func() error {
+			var callErr error
+			resp, callErr = client.UpdateRagDocumentBySearch(ctx, req)
+			return callErr
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("❌ Gagal update RAG document by search: %w", err)
 	}
@@ -113,8 +182,8 @@ func UpdateRagDocumentBySearch(tenantID, searchContent, newContent string) (*rag
 	return resp, nil
 }
 
-func UpdateRAGDocumentBySearch(tenantID, searchContent, newContent string) (string, error) {
-	resp, err := UpdateRagDocumentBySearch(tenantID, searchContent, newContent)
+func UpdateRAGDocumentBySearch(ctx context.Context, tenantID, searchContent, newContent string) (string, error) {
+	resp, err := UpdateRagDocumentBySearch(ctx, tenantID, searchContent, newContent)
 	if err != nil {
 		return "", err
 	}
@@ -123,51 +192,118 @@ func UpdateRAGDocumentBySearch(tenantID, searchContent, newContent string) (stri
 }
 
 
-func QueryRAG(query, tenantID string) (string, error) {
-    log.Printf("🔍 QueryRAG called with query: %s, tenant: %s", query, tenantID)
+func QueryRAG(ctx context.Context, query, tenantID string) (string, error) {
+    return QueryRAGWithOptions(ctx, query, tenantID, SearchOptions{})
+}
+
+// QueryRAGWithOptions: FuzzySearch dengan similarity threshold & top_k dari opts (nol = default global).
+// top_k > 1 menggabungkan konten beberapa dokumen teratas, dipisah baris kosong.
+func QueryRAGWithOptions(ctx context.Context, query, tenantID string, opts SearchOptions) (string, error) {
+    opts = opts.withDefaults()
+    utils.Component("ragclient").Info().Str("query", query).Str("tenant_id", tenantID).
+        Float32("similarity_threshold", opts.SimilarityThreshold).Int("top_k", opts.TopK).Msg("🔍 QueryRAG called")
+
+    // Cache opt-in via RAG_CACHE_ENABLED
+    cache := getQueryCache()
+    if tenantID == "" {
+        // Tanpa tenant tidak ada partisi cache, jadi jangan share hasil antar caller
+        cache = nil
+    }
+    key := cacheKey(tenantID, query) + opts.cacheSuffix()
+    if cache != nil {
+        if answer, ok := cache.Get(key); ok {
+            RagCacheHits.Inc()
+            utils.Component("ragclient").Info().Str("tenant_id", tenantID).Msg("⚡ QueryRAG cache hit")
+            return answer, nil
+        }
+        RagCacheMisses.Inc()
+    }
     
-    ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+    ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
     defer cancel()
     
-    log.Printf("🔗 Attempting gRPC call to ragcrud_service...")
+    utils.Component("ragclient").Debug().Msg("🔗 Attempting gRPC call to ragcrud_service...")
     
     // Use new FuzzySearchDocuments gRPC method
     req := &ragcrud_pb.FuzzySearchRequest{
         TenantId: tenantID,
         SearchContent: query,
-        SimilarityThreshold: 0.7,
+        SimilarityThreshold: opts.SimilarityThreshold,
     }
     
-    resp, err := getRagCrudClient().FuzzySearchDocuments(ctx, req)
+    client, breaker, err := ragCrudFor(tenantID)
+    if err != nil {
+        return "", err
+    }
+
+    var resp *ragcrud_pb.FuzzySearchResponse
+    err = breaker.Execute(func() error {
+        return ObserveCall("FuzzySearchDocuments", func() error {
+            var callErr error
+            resp, callErr = client.FuzzySearchDocuments(ctx, req)
+            return callErr
+        })
+    })
     if err != nil {
-        log.Printf("❌ FuzzySearch failed: %v", err)
+        utils.Component("ragclient").Error().Err(err).Msg("❌ FuzzySearch failed")
         return "", fmt.Errorf("❌ FuzzySearch failed: %w", err)
     }
     
-    log.Printf("✅ FuzzySearch success, found %d documents", len(resp.Documents))
+    utils.Component("ragclient").Info().Int("documents", len(resp.Documents)).Msg("✅ FuzzySearch success")
     
-    // Return first matching document
+    // Dokumen teratas (top_k) digabung; tanpa hasil → pesan fallback
+    answer := fmt.Sprintf("Tidak ditemukan FAQ untuk: %s", query)
     if len(resp.Documents) > 0 {
-        return resp.Documents[0].Content, nil
+        var contents []string
+        for i, doc := range resp.Documents {
+            if i >= opts.TopK {
+                break
+            }
+            contents = append(contents, doc.Content)
+        }
+        answer = strings.Join(contents, "\n\n")
     }
-    
-    return fmt.Sprintf("Tidak ditemukan FAQ untuk: %s", query), nil
+
+    if cache != nil {
+        cache.Set(key, answer)
+    }
+    return answer, nil
 }
 
 
-func CreateRagDocument(tenantID, title, content string) (*ragcrud_pb.RagDocumentResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// CreateRagDocument membuat dokumen RAG; source/tags kosong → default conversational_faq / [faq]
+func CreateRagDocument(ctx context.Context, tenantID, title, content, source string, tags []string) (*ragcrud_pb.RagDocumentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	if source == "" {
+		source = "conversational_faq"
+	}
+	if len(tags) == 0 {
+		tags = []string{"faq"}
+	}
+
 	req := &ragcrud_pb.CreateRagDocumentRequest{
 		TenantId: tenantID,
 		Title:    title,
 		Content:  content,
-		Source:   "conversational_faq",
-		Tags:     []string{"faq"},
+		Source:   source,
+		Tags:     tags,
 	}
 
-	resp, err := getRagCrudClient().CreateRagDocument(ctx, req)
+	client, breaker, err := ragCrudFor(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *ragcrud_pb.RagDocumentResponse
+	err = breaker.Execute(func() error {
+		return ObserveCall("CreateRagDocument", func() error {
+			var callErr error
+			resp, callErr = client.CreateRagDocument(ctx, req)
+			return callErr
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("❌ Gagal create RAG document: %w", err)
 	}
@@ -175,8 +311,8 @@ func CreateRagDocument(tenantID, title, content string) (*ragcrud_pb.RagDocument
 	return resp, nil
 }
 
-func CreateRAGDocument(tenantID, title, content string) (string, error) {
-	resp, err := CreateRagDocument(tenantID, title, content)
+func CreateRAGDocument(ctx context.Context, tenantID, title, content, source string, tags []string) (string, error) {
+	resp, err := CreateRagDocument(ctx, tenantID, title, content, source, tags)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,36 @@
+package ragclient
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// SearchOptions adalah tuning FuzzySearch per call; nilai nol = pakai default global
+// (RAG_SIMILARITY_THRESHOLD, RAG_TOP_K). Precedence node > tenant di-resolve oleh executor.
+type SearchOptions struct {
+	SimilarityThreshold float32
+	TopK                int
+}
+
+// withDefaults mengisi field kosong dengan default global dari ENV
+func (o SearchOptions) withDefaults() SearchOptions {
+	if o.SimilarityThreshold <= 0 {
+		o.SimilarityThreshold = 0.7
+		if v, err := strconv.ParseFloat(os.Getenv("RAG_SIMILARITY_THRESHOLD"), 32); err == nil && v > 0 {
+			o.SimilarityThreshold = float32(v)
+		}
+	}
+	if o.TopK <= 0 {
+		o.TopK = 1
+		if n, err := strconv.Atoi(os.Getenv("RAG_TOP_K")); err == nil && n > 0 {
+			o.TopK = n
+		}
+	}
+	return o
+}
+
+// cacheSuffix membedakan entry cache untuk query sama dengan tuning berbeda
+func (o SearchOptions) cacheSuffix() string {
+	return fmt.Sprintf("\x00%g\x00%d", o.SimilarityThreshold, o.TopK)
+}
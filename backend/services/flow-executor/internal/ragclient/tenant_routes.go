@@ -0,0 +1,123 @@
+package ragclient
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/grpc"
+	"gopkg.in/yaml.v2"
+
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Path default config routing RAG per tenant, override via ENV RAG_ROUTES_CONFIG
+const defaultRoutesPath = "backend/services/flow-executor/config/rag_routes.yaml"
+
+// TenantRoute adalah endpoint RAG khusus satu tenant (knowledge base terisolasi).
+// Field kosong → tenant tetap pakai endpoint default (RAGCRUD_GRPC_* / RAGLLM_GRPC_*).
+type TenantRoute struct {
+	RagCrud string `yaml:"ragcrud"`
+	RagLLM  string `yaml:"ragllm"`
+}
+
+type routesConfig struct {
+	Tenants map[string]TenantRoute `yaml:"tenants"`
+}
+
+var (
+	routesMu     sync.RWMutex
+	tenantRoutes map[string]TenantRoute
+
+	// Pool koneksi per endpoint (host:port), dipakai bersama oleh semua tenant yang menunjuk endpoint sama
+	poolMu   sync.Mutex
+	connPool = map[string]*grpc.ClientConn{}
+	breakers = map[string]*CircuitBreaker{}
+)
+
+// LoadTenantRoutes membaca mapping tenant → endpoint RAG dari RAG_ROUTES_CONFIG (YAML).
+// File tidak ada → semua tenant pakai endpoint default.
+func LoadTenantRoutes() error {
+	path := os.Getenv("RAG_ROUTES_CONFIG")
+	if path == "" {
+		path = defaultRoutesPath
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		utils.Component("ragclient").Info().Str("path", path).Msg("🧭 Config routing RAG per tenant tidak ada, semua tenant pakai endpoint default")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read RAG routes config: %w", err)
+	}
+
+	var cfg routesConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("failed to parse RAG routes config %s: %w", path, err)
+	}
+	SetTenantRoutes(cfg.Tenants)
+	utils.Component("ragclient").Info().Str("path", path).Int("tenants", len(cfg.Tenants)).Msg("🧭 Routing RAG per tenant dimuat")
+	return nil
+}
+
+// SetTenantRoutes mengganti mapping tenant → endpoint RAG (dipakai LoadTenantRoutes & test)
+func SetTenantRoutes(routes map[string]TenantRoute) {
+	routesMu.Lock()
+	defer routesMu.Unlock()
+	tenantRoutes = routes
+}
+
+// TenantRagCrudTarget: endpoint RAG CRUD khusus tenant, "" jika tenant tidak di-mapping
+func TenantRagCrudTarget(tenantID string) string {
+	routesMu.RLock()
+	defer routesMu.RUnlock()
+	return tenantRoutes[tenantID].RagCrud
+}
+
+// TenantRagLLMTarget: endpoint RAG LLM khusus tenant, "" jika tenant tidak di-mapping
+func TenantRagLLMTarget(tenantID string) string {
+	routesMu.RLock()
+	defer routesMu.RUnlock()
+	return tenantRoutes[tenantID].RagLLM
+}
+
+// PooledConn mengembalikan koneksi gRPC ke addr dari pool; dibuat lazy (tanpa block) saat pertama dipakai
+func PooledConn(addr string) (*grpc.ClientConn, error) {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if conn, ok := connPool[addr]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithInsecure(), tracing.DialOption())
+	if err != nil {
+		return nil, fmt.Errorf("❌ Gagal membuat koneksi RAG ke %s: %w", addr, err)
+	}
+	utils.Component("ragclient").Info().Str("addr", addr).Msg("🔗 Koneksi RAG tenant ditambahkan ke pool")
+	connPool[addr] = conn
+	return conn, nil
+}
+
+// BreakerFor mengembalikan circuit breaker per endpoint, supaya endpoint tenant yang down
+// tidak membuka sirkuit untuk tenant lain
+func BreakerFor(service, addr string) *CircuitBreaker {
+	name := service + "@" + addr
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	if cb, ok := breakers[name]; ok {
+		return cb
+	}
+	cb := NewCircuitBreaker(name)
+	breakers[name] = cb
+	return cb
+}
+
+// ClosePool menutup semua koneksi RAG tenant (dipanggil saat shutdown)
+func ClosePool() {
+	poolMu.Lock()
+	defer poolMu.Unlock()
+	for addr, conn := range connPool {
+		conn.Close()
+		delete(connPool, addr)
+	}
+}
@@ -0,0 +1,250 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/loader"
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Path default config jadwal, override via ENV SCHEDULE_CONFIG
+const defaultConfigPath = "backend/services/flow-executor/config/schedules.yaml"
+
+// Entry adalah satu jadwal: ekspresi cron → file flow → input statis
+type Entry struct {
+	Name  string                 `yaml:"name"`
+	Cron  string                 `yaml:"cron"`
+	Flow  string                 `yaml:"flow"`
+	Input map[string]interface{} `yaml:"input"`
+
+	spec   *cronSpec
+	path   string
+	flowID string
+}
+
+type config struct {
+	Schedules []*Entry `yaml:"schedules"`
+}
+
+// Scheduler menjalankan flow terjadwal; dibuat lewat Start dan dihentikan dengan cancel ctx + Wait.
+type Scheduler struct {
+	entries   []*Entry
+	statePath string
+	wg        sync.WaitGroup
+}
+
+// state menyimpan tick terakhir supaya run yang terlewat saat downtime bisa di-log
+type state struct {
+	LastTick time.Time `json:"last_tick"`
+}
+
+// Start memuat config dan menjalankan loop scheduler di goroutine.
+// Mengembalikan nil (scheduler nonaktif) jika file config tidak ada.
+func Start(ctx context.Context) (*Scheduler, error) {
+	logger := utils.Component("scheduler")
+
+	path := os.Getenv("SCHEDULE_CONFIG")
+	if path == "" {
+		path = defaultConfigPath
+	}
+	entries, err := loadConfig(path)
+	if os.IsNotExist(err) {
+		logger.Info().Str("path", path).Msg("⏰ Config jadwal tidak ada, scheduler nonaktif")
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	statePath := os.Getenv("SCHEDULER_STATE_FILE")
+	if statePath == "" {
+		statePath = path + ".state"
+	}
+
+	s := &Scheduler{entries: entries, statePath: statePath}
+	s.logMissedRuns(time.Now())
+
+	s.wg.Add(1)
+	go s.loop(ctx)
+
+	logger.Info().Int("schedules", len(entries)).Msg("⏰ Scheduler started")
+	return s, nil
+}
+
+// Wait menunggu loop dan semua run yang sedang jalan selesai (dipanggil setelah ctx di-cancel)
+func (s *Scheduler) Wait() {
+	if s != nil {
+		s.wg.Wait()
+	}
+}
+
+func loadConfig(path string) ([]*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse schedule config: %w", err)
+	}
+
+	for _, e := range cfg.Schedules {
+		if e.spec, err = parseCron(e.Cron); err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", e.Name, err)
+		}
+		if e.path, err = loader.ResolveFlowPath(e.Flow); err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", e.Name, err)
+		}
+		if e.Name == "" {
+			e.Name = e.Flow
+		}
+		e.Input = normalizeYAML(e.Input).(map[string]interface{})
+		e.flowID = readFlowID(e.path)
+	}
+	return cfg.Schedules, nil
+}
+
+// readFlowID mengambil flow_id untuk label metric; fallback ke path jika file belum ada
+func readFlowID(path string) string {
+	var spec struct {
+		FlowID string `json:"flow_id"`
+	}
+	if data, err := loader.ReadFlowJSON(path); err == nil && json.Unmarshal(data, &spec) == nil && spec.FlowID != "" {
+		return spec.FlowID
+	}
+	return path
+}
+
+// normalizeYAML mengubah map[interface{}]interface{} dari yaml.v2 menjadi map[string]interface{}
+// supaya input bisa dirender template & di-encode JSON seperti input HTTP.
+func normalizeYAML(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[fmt.Sprint(k)] = normalizeYAML(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			out[k] = normalizeYAML(item)
+		}
+		return out
+	case []interface{}:
+		for i, item := range val {
+			val[i] = normalizeYAML(item)
+		}
+		return val
+	case nil:
+		return map[string]interface{}{}
+	}
+	return v
+}
+
+func (s *Scheduler) loop(ctx context.Context) {
+	defer s.wg.Done()
+	logger := utils.Component("scheduler")
+	last := time.Now().Truncate(time.Minute)
+
+	for {
+		next := last.Add(time.Minute)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			logger.Info().Msg("🛑 Scheduler stopped")
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now().Truncate(time.Minute)
+		// Jika loop telat (mis. host suspend), menit yang terlewat hanya di-log, tidak di-fire
+		for missed := next; missed.Before(now); missed = missed.Add(time.Minute) {
+			s.logMissedAt(missed)
+		}
+
+		for _, e := range s.entries {
+			if e.spec.Match(now) {
+				s.wg.Add(1)
+				go s.run(ctx, e)
+			}
+		}
+		last = now
+		s.saveState(now)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, e *Entry) {
+	defer s.wg.Done()
+	logger := utils.Component("scheduler").With().Str("schedule", e.Name).Str("flow_id", e.flowID).Logger()
+
+	// Input disalin per run karena engine bisa menambah key ke map input
+	input := make(map[string]interface{}, len(e.Input))
+	for k, v := range e.Input {
+		input[k] = v
+	}
+
+	executionID := executor.NewExecutionID()
+	ctx = executor.WithExecutionID(ctx, executionID)
+	logger.Info().Str("execution_id", executionID).Msg("⏰ Running scheduled flow")
+
+	if _, err := executor.RunFlowAndReturnOutputContext(ctx, e.path, input); err != nil {
+		observer.ScheduledFlowRuns.WithLabelValues(e.flowID, "fail").Inc()
+		logger.Error().Err(err).Str("execution_id", executionID).Msg("❌ Scheduled flow failed")
+		return
+	}
+	observer.ScheduledFlowRuns.WithLabelValues(e.flowID, "success").Inc()
+	logger.Info().Str("execution_id", executionID).Msg("✅ Scheduled flow completed")
+}
+
+// logMissedRuns membandingkan tick terakhir yang tersimpan dengan sekarang dan me-log
+// jadwal yang jatuh saat service mati. Run tersebut sengaja tidak dijalankan ulang.
+func (s *Scheduler) logMissedRuns(now time.Time) {
+	data, err := os.ReadFile(s.statePath)
+	if err != nil {
+		return
+	}
+	var st state
+	if json.Unmarshal(data, &st) != nil || st.LastTick.IsZero() {
+		return
+	}
+
+	// Batasi scan supaya downtime panjang tidak membuat loop jutaan menit
+	from := st.LastTick.Add(time.Minute)
+	if limit := now.Add(-7 * 24 * time.Hour); from.Before(limit) {
+		utils.Component("scheduler").Warn().Time("last_tick", st.LastTick).Msg("⚠️ Downtime > 7 hari, missed run hanya di-scan 7 hari terakhir")
+		from = limit.Truncate(time.Minute)
+	}
+	for t := from; !t.After(now); t = t.Add(time.Minute) {
+		s.logMissedAt(t)
+	}
+}
+
+func (s *Scheduler) logMissedAt(t time.Time) {
+	for _, e := range s.entries {
+		if e.spec.Match(t) {
+			utils.Component("scheduler").Warn().
+				Str("schedule", e.Name).
+				Str("flow_id", e.flowID).
+				Time("scheduled_at", t).
+				Msg("⏭️ Missed scheduled run (not fired retroactively)")
+		}
+	}
+}
+
+func (s *Scheduler) saveState(t time.Time) {
+	data, _ := json.Marshal(state{LastTick: t})
+	if err := os.WriteFile(s.statePath, data, 0644); err != nil {
+		utils.Component("scheduler").Warn().Err(err).Msg("⚠️ Gagal simpan state scheduler")
+	}
+}
@@ -0,0 +1,194 @@
+package statestore
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix key checkpoint di Redis
+const keyPrefix = "flow-executor:checkpoint:"
+
+// Prefix key lock resume; TTL membatasi lock yatim jika instance mati di tengah resume
+const (
+	claimKeyPrefix = "flow-executor:resume-lock:"
+	claimTTL       = 10 * time.Minute
+)
+
+// RedisStore adalah StateStore di atas Redis, memakai client RESP minimal (SET/GET/DEL)
+// dengan satu koneksi yang di-serialize mutex dan reconnect otomatis setelah error.
+type RedisStore struct {
+	addr     string
+	password string
+	db       int
+	ttl      time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+	rd   *bufio.Reader
+}
+
+func NewRedisStore(addr, password string, db int, ttl time.Duration) *RedisStore {
+	return &RedisStore{addr: addr, password: password, db: db, ttl: ttl}
+}
+
+func (s *RedisStore) SaveCheckpoint(ctx context.Context, executionID string, state []byte) error {
+	_, err := s.do(ctx, "SET", keyPrefix+executionID, string(state), "PX", strconv.FormatInt(s.ttl.Milliseconds(), 10))
+	return err
+}
+
+func (s *RedisStore) LoadCheckpoint(ctx context.Context, executionID string) ([]byte, error) {
+	reply, err := s.do(ctx, "GET", keyPrefix+executionID)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNotFound
+	}
+	return []byte(reply.(string)), nil
+}
+
+func (s *RedisStore) DeleteCheckpoint(ctx context.Context, executionID string) error {
+	_, err := s.do(ctx, "DEL", keyPrefix+executionID)
+	return err
+}
+
+// ClaimCheckpoint memakai SET NX, jadi hanya satu resume per execution_id yang menang
+func (s *RedisStore) ClaimCheckpoint(ctx context.Context, executionID string) (bool, error) {
+	reply, err := s.do(ctx, "SET", claimKeyPrefix+executionID, "1", "NX", "PX", strconv.FormatInt(claimTTL.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+func (s *RedisStore) ReleaseCheckpoint(ctx context.Context, executionID string) error {
+	_, err := s.do(ctx, "DEL", claimKeyPrefix+executionID)
+	return err
+}
+
+// do mengirim satu command dan membaca reply-nya; koneksi ditutup jika terjadi error I/O
+func (s *RedisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	s.conn.SetDeadline(deadline)
+
+	reply, err := s.roundTrip(args...)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return reply, err
+}
+
+func (s *RedisStore) connect(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("redis dial %s: %w", s.addr, err)
+	}
+	s.conn = conn
+	s.rd = bufio.NewReader(conn)
+
+	if s.password != "" {
+		if _, err := s.roundTrip("AUTH", s.password); err != nil {
+			conn.Close()
+			s.conn = nil
+			return fmt.Errorf("redis auth: %w", err)
+		}
+	}
+	if s.db != 0 {
+		if _, err := s.roundTrip("SELECT", strconv.Itoa(s.db)); err != nil {
+			conn.Close()
+			s.conn = nil
+			return fmt.Errorf("redis select: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *RedisStore) roundTrip(args ...string) (interface{}, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := io.WriteString(s.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readReply(s.rd)
+}
+
+// redisError adalah reply "-ERR ..." dari server (koneksi tetap valid)
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// readReply mem-parse satu reply RESP2: simple string, error, integer, bulk string, array
+func readReply(rd *bufio.Reader) (interface{}, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(rd, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = readReply(rd); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
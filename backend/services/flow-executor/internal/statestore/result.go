@@ -0,0 +1,83 @@
+package statestore
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Prefix key hasil eksekusi (idempotency) di Redis
+const resultKeyPrefix = "flow-executor:result:"
+
+// ResultStore menyimpan hasil eksekusi yang sudah di-serialize dengan TTL per key
+// (dipakai untuk replay Idempotency-Key).
+type ResultStore interface {
+	SaveResult(ctx context.Context, key string, data []byte, ttl time.Duration) error
+	LoadResult(ctx context.Context, key string) ([]byte, error)
+}
+
+// ResultStoreFor memakai store yang sama dengan checkpoint jika mendukung ResultStore (Redis),
+// selain itu fallback ke MemoryResultStore (hanya berlaku per instance).
+func ResultStoreFor(s StateStore) ResultStore {
+	if rs, ok := s.(ResultStore); ok {
+		return rs
+	}
+	return NewMemoryResultStore()
+}
+
+func (s *RedisStore) SaveResult(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, "SET", resultKeyPrefix+key, string(data), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+func (s *RedisStore) LoadResult(ctx context.Context, key string) ([]byte, error) {
+	reply, err := s.do(ctx, "GET", resultKeyPrefix+key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNotFound
+	}
+	return []byte(reply.(string)), nil
+}
+
+type memoryResult struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// MemoryResultStore adalah ResultStore in-memory; entry expired dibuang saat Save berikutnya
+type MemoryResultStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryResult
+}
+
+func NewMemoryResultStore() *MemoryResultStore {
+	return &MemoryResultStore{entries: map[string]memoryResult{}}
+}
+
+func (s *MemoryResultStore) SaveResult(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range s.entries {
+		if now.After(e.expiresAt) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = memoryResult{data: data, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *MemoryResultStore) LoadResult(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, ErrNotFound
+	}
+	return e.data, nil
+}
@@ -0,0 +1,73 @@
+package statestore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// ErrNotFound dikembalikan LoadCheckpoint jika execution_id tidak punya checkpoint
+var ErrNotFound = errors.New("checkpoint not found")
+
+// StateStore menyimpan checkpoint eksekusi flow (state sudah di-serialize oleh executor)
+// supaya flow yang terputus di tengah bisa dilanjutkan dengan ResumeFlow.
+type StateStore interface {
+	SaveCheckpoint(ctx context.Context, executionID string, state []byte) error
+	LoadCheckpoint(ctx context.Context, executionID string) ([]byte, error)
+	DeleteCheckpoint(ctx context.Context, executionID string) error
+
+	// ClaimCheckpoint mengunci execution_id untuk satu resume (atomic); false jika sedang
+	// di-resume request lain. Lock dilepas lewat ReleaseCheckpoint atau expired sendiri.
+	ClaimCheckpoint(ctx context.Context, executionID string) (bool, error)
+	ReleaseCheckpoint(ctx context.Context, executionID string) error
+}
+
+// NoopStore adalah default: tidak menyimpan apapun, perilaku sama seperti sebelum ada checkpoint
+type NoopStore struct{}
+
+func (NoopStore) SaveCheckpoint(ctx context.Context, executionID string, state []byte) error {
+	return nil
+}
+
+func (NoopStore) LoadCheckpoint(ctx context.Context, executionID string) ([]byte, error) {
+	return nil, ErrNotFound
+}
+
+func (NoopStore) DeleteCheckpoint(ctx context.Context, executionID string) error {
+	return nil
+}
+
+func (NoopStore) ClaimCheckpoint(ctx context.Context, executionID string) (bool, error) {
+	return true, nil
+}
+
+func (NoopStore) ReleaseCheckpoint(ctx context.Context, executionID string) error {
+	return nil
+}
+
+// FromEnv memilih store dari ENV STATE_STORE ("redis" atau kosong = noop).
+// Redis: REDIS_ADDR (default redis:6379), REDIS_PASSWORD, REDIS_DB, STATE_STORE_TTL (default 24h).
+func FromEnv() StateStore {
+	if os.Getenv("STATE_STORE") != "redis" {
+		return NoopStore{}
+	}
+
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "redis:6379"
+	}
+	db, _ := strconv.Atoi(os.Getenv("REDIS_DB"))
+	ttl := 24 * time.Hour
+	if v := os.Getenv("STATE_STORE_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			ttl = d
+		}
+	}
+
+	utils.Component("statestore").Info().Str("addr", addr).Dur("ttl", ttl).Msg("💾 Redis checkpoint store aktif")
+	return NewRedisStore(addr, os.Getenv("REDIS_PASSWORD"), db, ttl)
+}
@@ -0,0 +1,255 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+const (
+	queueSize     = 2048
+	maxBatch      = 512
+	flushInterval = 5 * time.Second
+)
+
+// exporter mengirim span secara batch ke OTLP/HTTP (JSON) collector
+type exporter struct {
+	url     string
+	headers map[string]string
+	service string
+	client  *http.Client
+
+	queue chan *Span
+	flush chan chan struct{}
+	done  chan struct{}
+}
+
+var (
+	mu  sync.RWMutex
+	exp *exporter
+)
+
+// Enabled: true jika exporter OTLP aktif (InitFromEnv menemukan endpoint)
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return exp != nil
+}
+
+// InitFromEnv mengaktifkan export span dari ENV standar OpenTelemetry:
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT (URL lengkap) atau OTEL_EXPORTER_OTLP_ENDPOINT (+ /v1/traces),
+// OTEL_EXPORTER_OTLP_HEADERS ("k=v,k2=v2"), OTEL_SERVICE_NAME (default flow-executor).
+// Tanpa endpoint, tracing nonaktif.
+func InitFromEnv() {
+	url := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if url == "" {
+		if base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); base != "" {
+			url = strings.TrimRight(base, "/") + "/v1/traces"
+		}
+	}
+	logger := utils.Component("tracing")
+	if url == "" {
+		logger.Info().Msg("🔭 OTEL_EXPORTER_OTLP_ENDPOINT tidak diset, tracing nonaktif")
+		return
+	}
+
+	service := os.Getenv("OTEL_SERVICE_NAME")
+	if service == "" {
+		service = "flow-executor"
+	}
+	headers := map[string]string{}
+	for _, kv := range strings.Split(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), ",") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+
+	e := &exporter{
+		url:     url,
+		headers: headers,
+		service: service,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		queue:   make(chan *Span, queueSize),
+		flush:   make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go e.loop()
+
+	mu.Lock()
+	exp = e
+	mu.Unlock()
+	logger.Info().Str("endpoint", url).Str("service", service).Msg("🔭 OTLP trace exporter aktif")
+}
+
+// Shutdown mengirim span yang tersisa lalu mematikan exporter (dipanggil saat shutdown)
+func Shutdown(ctx context.Context) {
+	mu.Lock()
+	e := exp
+	exp = nil
+	mu.Unlock()
+	if e == nil {
+		return
+	}
+	ack := make(chan struct{})
+	select {
+	case e.flush <- ack:
+		select {
+		case <-ack:
+		case <-ctx.Done():
+		}
+	case <-ctx.Done():
+	}
+	close(e.done)
+}
+
+func enqueue(s *Span) {
+	mu.RLock()
+	e := exp
+	mu.RUnlock()
+	if e == nil {
+		return
+	}
+	select {
+	case e.queue <- s:
+	default:
+		// Queue penuh (collector lambat/down): span di-drop, eksekusi flow tidak boleh tertahan
+	}
+}
+
+func (e *exporter) loop() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	var batch []*Span
+	for {
+		select {
+		case s := <-e.queue:
+			batch = append(batch, s)
+			if len(batch) >= maxBatch {
+				e.send(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			e.send(batch)
+			batch = nil
+		case ack := <-e.flush:
+			for drained := false; !drained; {
+				select {
+				case s := <-e.queue:
+					batch = append(batch, s)
+				default:
+					drained = true
+				}
+			}
+			e.send(batch)
+			batch = nil
+			close(ack)
+		case <-e.done:
+			return
+		}
+	}
+}
+
+func (e *exporter) send(batch []*Span) {
+	if len(batch) == 0 {
+		return
+	}
+	body, err := json.Marshal(e.payload(batch))
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		utils.Component("tracing").Warn().Err(err).Int("spans", len(batch)).Msg("⚠️ Gagal export span OTLP")
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		utils.Component("tracing").Warn().Int("status", resp.StatusCode).Int("spans", len(batch)).Msg("⚠️ Collector OTLP menolak span")
+	}
+}
+
+// Struktur JSON OTLP (ExportTraceServiceRequest); id di-encode hex sesuai JSON mapping OTLP
+type otlpAttr struct {
+	Key   string            `json:"key"`
+	Value map[string]string `json:"value"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              SpanKind   `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []otlpAttr `json:"attributes,omitempty"`
+	Status            otlpStatus `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func (e *exporter) payload(batch []*Span) map[string]interface{} {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, s := range batch {
+		s.mu.Lock()
+		o := otlpSpan{
+			TraceID:           hex.EncodeToString(s.TraceID[:]),
+			SpanID:            hex.EncodeToString(s.SpanID[:]),
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+			Status:            otlpStatus{Code: 1},
+		}
+		if s.ParentSpanID != ([8]byte{}) {
+			o.ParentSpanID = hex.EncodeToString(s.ParentSpanID[:])
+		}
+		if s.errMsg != "" {
+			o.Status = otlpStatus{Code: 2, Message: s.errMsg}
+		}
+		keys := make([]string, 0, len(s.attrs))
+		for k := range s.attrs {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			o.Attributes = append(o.Attributes, otlpAttr{Key: k, Value: map[string]string{"stringValue": s.attrs[k]}})
+		}
+		s.mu.Unlock()
+		spans = append(spans, o)
+	}
+
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": []otlpAttr{{Key: "service.name", Value: map[string]string{"stringValue": e.service}}},
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]string{"name": fmt.Sprintf("%s/tracing", e.service)},
+				"spans": spans,
+			}},
+		}},
+	}
+}
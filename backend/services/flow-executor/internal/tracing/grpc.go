@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryClientInterceptor membuat span client per gRPC call dan mengirim traceparent
+// di metadata, supaya service downstream bisa melanjutkan trace yang sama
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := Start(ctx, method, KindClient)
+		if span == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		defer span.End()
+		span.SetAttr("rpc.system", "grpc")
+		span.SetAttr("rpc.method", method)
+		span.SetAttr("net.peer.name", cc.Target())
+
+		ctx = metadata.AppendToOutgoingContext(ctx, "traceparent", Traceparent(ctx))
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		span.RecordError(err)
+		return err
+	}
+}
+
+// UnaryServerInterceptor membuat span server per RPC masuk, melanjutkan traceparent dari caller
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if tp := md.Get("traceparent"); len(tp) > 0 {
+				ctx = WithTraceparent(ctx, tp[0])
+			}
+		}
+		ctx, span := Start(ctx, info.FullMethod, KindServer)
+		defer span.End()
+		span.SetAttr("rpc.system", "grpc")
+		span.SetAttr("rpc.method", info.FullMethod)
+
+		resp, err := handler(ctx, req)
+		span.RecordError(err)
+		return resp, err
+	}
+}
+
+// DialOption memasang UnaryClientInterceptor ke koneksi gRPC keluar
+func DialOption() grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(UnaryClientInterceptor())
+}
@@ -0,0 +1,153 @@
+// Package tracing membuat span distributed tracing (W3C trace context) dan mengekspornya
+// ke collector OpenTelemetry lewat OTLP/HTTP JSON. Tanpa OTEL_EXPORTER_OTLP_ENDPOINT,
+// span tidak dibuat sama sekali (Start mengembalikan nil span, semua method nil-safe).
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SpanKind mengikuti enum OTLP
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// Span adalah satu operasi bertimer; dibuat lewat Start dan wajib ditutup dengan End
+type Span struct {
+	TraceID      [16]byte
+	SpanID       [8]byte
+	ParentSpanID [8]byte
+	Name         string
+	Kind         SpanKind
+	Start        time.Time
+	EndTime      time.Time
+
+	mu     sync.Mutex
+	attrs  map[string]string
+	errMsg string
+	ended  bool
+}
+
+type spanCtxKey struct{}
+
+// remoteParent adalah parent dari traceparent yang masuk (HTTP header / gRPC metadata)
+type remoteParent struct {
+	traceID [16]byte
+	spanID  [8]byte
+}
+
+type remoteCtxKey struct{}
+
+// Start membuat span anak dari span di ctx (atau dari traceparent remote, atau trace baru)
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if !Enabled() {
+		return ctx, nil
+	}
+	s := &Span{Name: name, Kind: kind, Start: time.Now(), attrs: map[string]string{}}
+	if parent := FromContext(ctx); parent != nil {
+		s.TraceID = parent.TraceID
+		s.ParentSpanID = parent.SpanID
+	} else if remote, ok := ctx.Value(remoteCtxKey{}).(remoteParent); ok {
+		s.TraceID = remote.traceID
+		s.ParentSpanID = remote.spanID
+	} else {
+		rand.Read(s.TraceID[:])
+	}
+	rand.Read(s.SpanID[:])
+	return context.WithValue(ctx, spanCtxKey{}, s), s
+}
+
+// FromContext mengembalikan span aktif di ctx, nil jika tidak ada
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanCtxKey{}).(*Span)
+	return s
+}
+
+// SetAttr menambah atribut string ke span (node_id, hoop, tenant_id, dll)
+func (s *Span) SetAttr(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs[key] = value
+}
+
+// RecordError menandai span gagal (status ERROR di OTLP)
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errMsg = err.Error()
+}
+
+// End menutup span dan mengantrekannya ke exporter; panggilan kedua diabaikan
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.EndTime = time.Now()
+	s.mu.Unlock()
+	enqueue(s)
+}
+
+// TraceIDHex: trace id untuk korelasi log, kosong jika span nil
+func (s *Span) TraceIDHex() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.TraceID[:])
+}
+
+// Traceparent memformat header W3C traceparent untuk span di ctx ("" jika tidak ada span)
+func Traceparent(ctx context.Context) string {
+	s := FromContext(ctx)
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.TraceID[:]), hex.EncodeToString(s.SpanID[:]))
+}
+
+// WithTraceparent memasang parent remote dari header traceparent; header invalid diabaikan
+func WithTraceparent(ctx context.Context, header string) context.Context {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return ctx
+	}
+	var p remoteParent
+	if _, err := hex.Decode(p.traceID[:], []byte(parts[1])); err != nil {
+		return ctx
+	}
+	if _, err := hex.Decode(p.spanID[:], []byte(parts[2])); err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteCtxKey{}, p)
+}
+
+// StartHTTP membuat root span server untuk request HTTP, melanjutkan traceparent dari client
+func StartHTTP(r *http.Request, name string) (context.Context, *Span) {
+	ctx := WithTraceparent(r.Context(), r.Header.Get("traceparent"))
+	ctx, span := Start(ctx, name, KindServer)
+	span.SetAttr("http.method", r.Method)
+	span.SetAttr("http.target", r.URL.Path)
+	return ctx, span
+}
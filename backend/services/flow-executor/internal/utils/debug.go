@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"sync"
+)
+
+var (
+	debugOnce    sync.Once
+	debugEnabled bool
+)
+
+// DebugEnabled true jika ENV FLOW_DEBUG=true: mengaktifkan print debug templating
+// dan endpoint /debug/*. Default mati karena output-nya bisa berisi data user.
+func DebugEnabled() bool {
+	debugOnce.Do(func() {
+		debugEnabled, _ = strconv.ParseBool(os.Getenv("FLOW_DEBUG"))
+	})
+	return debugEnabled
+}
@@ -1,17 +1,68 @@
 package utils
 
 import (
+	"context"
 	"os"
+	"strings"
 
 	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v2"
 )
 
 var Log zerolog.Logger
 
+// Path config opsional untuk log_level
+const appConfigPath = "backend/services/flow-executor/config/app_config.yaml"
+
+type appConfig struct {
+	LogLevel string `yaml:"log_level"`
+}
+
+// InitLogger menyiapkan logger JSON tunggal untuk seluruh flow-executor.
+// Level: default info → log_level di config YAML → override ENV LOG_LEVEL.
 func InitLogger(service string) {
-	Log = zerolog.New(os.Stdout).
+	// Output dibungkus redactWriter supaya secret dari template tidak bocor ke log
+	Log = zerolog.New(redactWriter{out: os.Stdout}).
+		Level(resolveLogLevel()).
 		With().
 		Timestamp().
 		Str("service", service).
 		Logger()
 }
+
+// Component mengembalikan sub-logger dengan field component (mis. "ragclient", "delivery").
+func Component(name string) *zerolog.Logger {
+	l := Log.With().Str("component", name).Logger()
+	return &l
+}
+
+// FromContext mengambil logger yang disisipkan via zerolog WithContext, fallback ke Log global.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if l := zerolog.Ctx(ctx); l.GetLevel() != zerolog.Disabled {
+		return l
+	}
+	return &Log
+}
+
+func resolveLogLevel() zerolog.Level {
+	level := zerolog.InfoLevel
+
+	// ✅ Coba baca dari config YAML
+	if content, err := os.ReadFile(appConfigPath); err == nil {
+		var cfg appConfig
+		if yamlErr := yaml.Unmarshal(content, &cfg); yamlErr == nil {
+			if parsed, err := zerolog.ParseLevel(strings.ToLower(cfg.LogLevel)); err == nil && cfg.LogLevel != "" {
+				level = parsed
+			}
+		}
+	}
+
+	// ⛳ Jika ada ENV LOG_LEVEL, override config
+	if levelStr := os.Getenv("LOG_LEVEL"); levelStr != "" {
+		if parsed, err := zerolog.ParseLevel(strings.ToLower(levelStr)); err == nil {
+			level = parsed
+		}
+	}
+
+	return level
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Secret lebih pendek dari ini tidak di-redact supaya tidak mengganti substring umum
+const minRedactLen = 4
+
+const redactedMask = "***REDACTED***"
+
+var (
+	secretsMu sync.RWMutex
+	secrets   = map[string]struct{}{}
+)
+
+// RegisterSecret menandai value sebagai rahasia; setelah itu value tersebut (dan bentuk
+// JSON-escaped-nya) diganti mask di semua output log dan di string yang lewat Redact.
+func RegisterSecret(value string) {
+	if len(value) < minRedactLen {
+		return
+	}
+	escaped, _ := json.Marshal(value)
+	secretsMu.Lock()
+	secrets[value] = struct{}{}
+	if e := string(escaped[1 : len(escaped)-1]); e != value {
+		secrets[e] = struct{}{}
+	}
+	secretsMu.Unlock()
+}
+
+// Redact mengganti semua secret yang terdaftar di s dengan mask
+func Redact(s string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	for secret := range secrets {
+		if strings.Contains(s, secret) {
+			s = strings.ReplaceAll(s, secret, redactedMask)
+		}
+	}
+	return s
+}
+
+// redactWriter membungkus output logger supaya secret tidak pernah tertulis ke log
+type redactWriter struct {
+	out io.Writer
+}
+
+func (w redactWriter) Write(p []byte) (int, error) {
+	secretsMu.RLock()
+	empty := len(secrets) == 0
+	secretsMu.RUnlock()
+	if empty {
+		return w.out.Write(p)
+	}
+	if _, err := io.WriteString(w.out, Redact(string(p))); err != nil {
+		return 0, err
+	}
+	// zerolog mengharapkan panjang input, bukan panjang hasil redact
+	return len(p), nil
+}
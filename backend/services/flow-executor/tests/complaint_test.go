@@ -6,12 +6,12 @@ import (
 	"testing"
 
 	"github.com/milkyhoop/flow-executor/internal/executor"
-	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/utils"
 )
 
 func TestComplaintFlow(t *testing.T) {
 	// ✅ Init logger dulu (wajib sebelum RunFlow)
-	observer.InitLogger("flow-executor-test")
+	utils.InitLogger("flow-executor-test")
 
 	// Inject input test
 	input := map[string]interface{}{
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/milkyhoop/flow-executor/internal/delivery"
+	"github.com/milkyhoop/flow-executor/internal/observer"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+const partialFlow = `{
+  "flow_id": "partial-demo",
+  "context": {"outputs": {}},
+  "nodes": [
+    {"id": "boom", "hoop": "Reduce", "parameters": {"operation": "avg", "items": []}},
+    {"id": "bye", "hoop": "StaticReply", "parameters": {"message": "sampai jumpa"}}
+  ]
+}`
+
+func TestContinueOnErrorReportsPartial(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	writeExampleFlow(t, "partial-demo.json", partialFlow)
+
+	counter := func(status string) float64 {
+		return testutil.ToFloat64(observer.FlowExecutionCount.WithLabelValues("partial-demo", status, observer.TenantLabel("")))
+	}
+	successBefore, partialBefore := counter("success"), counter("partial")
+
+	req := httptest.NewRequest(http.MethodPost, "/run-flow/partial-demo.json?continue_on_error=true", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	delivery.HandleRunFlow(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	var resp delivery.FlowResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "partial" || len(resp.Errors) != 1 || resp.Errors[0].NodeID != "boom" {
+		t.Errorf("response = %+v", resp)
+	}
+	if got := counter("partial") - partialBefore; got != 1 {
+		t.Errorf("flow_execution_total{status=partial} naik %v, harusnya 1", got)
+	}
+	if got := counter("success") - successBefore; got != 0 {
+		t.Errorf("flow_execution_total{status=success} naik %v, harusnya 0", got)
+	}
+}
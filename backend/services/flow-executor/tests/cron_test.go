@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/scheduler"
+)
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expr := range []string{
+		"* * * *",     // 4 field
+		"60 * * * *",  // menit > 59
+		"* 24 * * *",  // jam > 23
+		"* * 0 * *",   // tanggal < 1
+		"* * * 13 *",  // bulan > 12
+		"* * * * 8",   // hari > 7
+		"*/0 * * * *", // step 0
+		"5-1 * * * *", // range terbalik
+		"a * * * *",   // bukan angka
+		"1-x * * * *", // range rusak
+		"@every 5m",   // macro tidak didukung
+		"* * * FOO *", // nama bulan tidak dikenal
+		"* * * * MON-XYZ",
+		"JAN * * * *", // nama hanya untuk bulan & hari
+		"0 0 L * *",   // karakter Quartz tidak didukung
+	} {
+		if _, err := scheduler.ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) harus error", expr)
+		}
+	}
+}
+
+func TestCronMatch(t *testing.T) {
+	// 2024-01-01 Senin, 2024-01-05 Jumat, 2024-01-07 Minggu, 2024-01-13 Sabtu
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name string
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"hari kerja cocok", "0 9 * * 1-5", at(1, 9, 0), true},
+		{"hari kerja, Minggu", "0 9 * * 1-5", at(7, 9, 0), false},
+		{"menit beda", "0 9 * * 1-5", at(1, 9, 1), false},
+
+		// tanggal & hari dua-duanya dibatasi → cukup salah satu (OR)
+		{"dom/dow OR: tanggal 13 hari Sabtu", "0 0 13 * 5", at(13, 0, 0), true},
+		{"dom/dow OR: Jumat tanggal 5", "0 0 13 * 5", at(5, 0, 0), true},
+		{"dom/dow OR: bukan keduanya", "0 0 13 * 5", at(6, 0, 0), false},
+		// salah satu * → dua-duanya harus cocok (AND)
+		{"dom saja: Jumat bukan tanggal 13", "0 0 13 * *", at(5, 0, 0), false},
+		{"dom saja: tanggal 13", "0 0 13 * *", at(13, 0, 0), true},
+		{"dow saja: tanggal 13 hari Sabtu", "0 0 * * 5", at(13, 0, 0), false},
+		// */n diawali * → tidak membatasi untuk aturan OR (Vixie cron), jadi tetap AND
+		{"dom */2 & Jumat: tanggal 5 Jumat", "0 0 */2 * 5", at(5, 0, 0), true},
+		{"dom */2 & Jumat: tanggal 12 Jumat", "0 0 */2 * 5", at(12, 0, 0), false},
+		{"dom */2 & Jumat: tanggal 3 Rabu", "0 0 */2 * 5", at(3, 0, 0), false},
+		{"dow */2 & tanggal 13: Sabtu 13", "0 0 13 * */2", at(13, 0, 0), true},
+		{"dow */2 & tanggal 13: Selasa 2", "0 0 13 * */2", at(2, 0, 0), false},
+
+		// 7 = Minggu, sama dengan 0
+		{"7 = Minggu", "0 0 * * 7", at(7, 0, 0), true},
+		{"0 = Minggu", "0 0 * * 0", at(7, 0, 0), true},
+		{"range 5-7 mencakup Minggu", "0 0 * * 5-7", at(7, 0, 0), true},
+		{"range 5-7 bukan Kamis", "0 0 * * 5-7", at(4, 0, 0), false},
+		{"7 bukan Sabtu", "0 0 * * 7", at(13, 0, 0), false},
+
+		// step
+		{"*/15 di menit 45", "*/15 * * * *", at(1, 10, 45), true},
+		{"*/15 di menit 50", "*/15 * * * *", at(1, 10, 50), false},
+		{"5/20 di menit 25", "5/20 * * * *", at(1, 10, 25), true},
+		{"5/20 di menit 5", "5/20 * * * *", at(1, 10, 5), true},
+		{"5/20 di menit 15", "5/20 * * * *", at(1, 10, 15), false},
+		{"range step 8-18/5 jam 13", "0 8-18/5 * * *", at(1, 13, 0), true},
+		{"range step 8-18/5 jam 14", "0 8-18/5 * * *", at(1, 14, 0), false},
+		{"range step 8-18/5 jam 18", "0 8-18/5 * * *", at(1, 18, 0), true},
+
+		// nama bulan & hari
+		{"JAN MON-FRI hari Senin", "0 9 * JAN MON-FRI", at(1, 9, 0), true},
+		{"JAN MON-FRI hari Minggu", "0 9 * JAN MON-FRI", at(7, 9, 0), false},
+		{"nama lowercase sun", "0 0 * * sun", at(7, 0, 0), true},
+		{"FEB di Januari", "0 0 1 FEB *", at(1, 0, 0), false},
+
+		// list, bulan, macro
+		{"list 0,30", "0,30 * * * *", at(1, 10, 30), true},
+		{"bulan Februari di Januari", "0 0 1 2 *", at(1, 0, 0), false},
+		{"@weekly hari Minggu", "@weekly", at(7, 0, 0), true},
+		{"@weekly hari Senin", "@weekly", at(1, 0, 0), false},
+		{"@hourly", "@hourly", at(1, 10, 0), true},
+		{"@hourly menit 1", "@hourly", at(1, 10, 1), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := scheduler.ParseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tc.expr, err)
+			}
+			if got := s.Match(tc.t); got != tc.want {
+				t.Errorf("%q Match(%s) = %v, want %v", tc.expr, tc.t.Format("Mon 2006-01-02 15:04"), got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/observer"
+)
+
+func TestEntryNodeIgnoresArrayOrder(t *testing.T) {
+	// greet ditulis terakhir di array, tapi satu-satunya node yang tidak ditunjuk edge
+	flow := executor.FlowSpec{
+		FlowID: "reordered",
+		Nodes: []executor.Node{
+			{ID: "reply", Hoop: "LogComplaint", InputFrom: "greet"},
+			{ID: "greet", Hoop: "LogComplaint", TruePath: "reply"},
+		},
+	}
+	entry, err := executor.EntryNode(flow)
+	if err != nil || entry != "greet" {
+		t.Fatalf("EntryNode = %q, %v; want greet", entry, err)
+	}
+
+	flow.StartNode = "reply"
+	if entry, _ := executor.EntryNode(flow); entry != "reply" {
+		t.Errorf("start_node diabaikan: got %q", entry)
+	}
+}
+
+func TestEntryNodeAmbiguous(t *testing.T) {
+	flow := executor.FlowSpec{
+		FlowID: "ambiguous",
+		Nodes: []executor.Node{
+			{ID: "a", Hoop: "LogComplaint", TruePath: "c"},
+			{ID: "b", Hoop: "LogComplaint", TruePath: "c"},
+			{ID: "c", Hoop: "LogComplaint"},
+		},
+	}
+	if _, err := executor.EntryNode(flow); !errors.Is(err, executor.ErrInvalidEntry) {
+		t.Errorf("err = %v, want ErrInvalidEntry", err)
+	}
+}
+
+func TestEntryNodeFailureCountsAsFailedExecution(t *testing.T) {
+	writeExampleFlow(t, "ambiguous-entry.json", `{
+  "flow_id": "ambiguous-entry",
+  "context": {"outputs": {}},
+  "nodes": [
+    {"id": "a", "hoop": "StaticReply", "parameters": {"message": "a"}, "true_path": "c"},
+    {"id": "b", "hoop": "StaticReply", "parameters": {"message": "b"}, "true_path": "c"},
+    {"id": "c", "hoop": "StaticReply", "parameters": {"message": "c"}}
+  ]
+}`)
+	failed := observer.FlowExecutionCount.WithLabelValues("ambiguous-entry", "fail", observer.TenantLabel(""))
+	before := testutil.ToFloat64(failed)
+
+	_, err := executor.RunFlowAndReturnOutput(filepath.Join(testFlowsDir, "examples", "ambiguous-entry.json"), map[string]interface{}{})
+	if !errors.Is(err, executor.ErrInvalidEntry) {
+		t.Fatalf("err = %v, want ErrInvalidEntry", err)
+	}
+	if got := testutil.ToFloat64(failed) - before; got != 1 {
+		t.Errorf("flow_execution_total{status=fail} naik %v, harusnya 1", got)
+	}
+}
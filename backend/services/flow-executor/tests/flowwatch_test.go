@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/milkyhoop/flow-executor/internal/flowwatch"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+func TestFlowWatcherReactsToFileEvents(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("file event watcher hanya di Linux")
+	}
+	utils.InitLogger("flow-executor-test")
+	// Interval polling sengaja panjang: perubahan harus terdeteksi lewat event, bukan polling
+	t.Setenv("FLOW_WATCH_INTERVAL", "1h")
+	writeExampleFlow(t, "watch-seed.json", wsFlow)
+
+	changed := make(chan string, 16)
+	flowwatch.OnInvalidate(func(path string) {
+		select {
+		case changed <- path:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := flowwatch.Start(ctx)
+	defer func() {
+		cancel()
+		w.Wait()
+	}()
+
+	writeExampleFlow(t, "watch-new.json", wsFlow)
+	want := filepath.Join(testFlowsDir, "examples", "watch-new.json")
+
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case path := <-changed:
+			if path == want {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("watcher tidak mendeteksi %s", want)
+		}
+	}
+}
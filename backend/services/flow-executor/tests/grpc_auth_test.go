@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/milkyhoop/flow-executor/internal/delivery"
+	pb "github.com/milkyhoop/flow-executor/internal/proto/flow_executor"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+func TestGRPCExecuteFlowRequiresAPIKeyAndTenant(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	writeExampleFlow(t, "grpc-demo.json", wsFlow)
+
+	interceptor := delivery.APIKeyUnaryInterceptor([]delivery.APIKey{
+		{Key: "admin-key"},
+		{Key: "tenant-key", Tenant: "tenant-a"},
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/flow_executor.FlowExecutorService/ExecuteFlow"}
+	server := &delivery.FlowExecutorServer{}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return server.ExecuteFlow(ctx, req.(*pb.ExecuteFlowRequest))
+	}
+
+	call := func(key, inputJSON string) error {
+		ctx := context.Background()
+		if key != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", key))
+		}
+		_, err := interceptor(ctx, &pb.ExecuteFlowRequest{FlowName: "grpc-demo.json", InputJson: inputJSON}, info, handler)
+		return err
+	}
+
+	if code := status.Code(call("", "")); code != codes.Unauthenticated {
+		t.Errorf("tanpa key: code = %v", code)
+	}
+	if code := status.Code(call("salah", "")); code != codes.Unauthenticated {
+		t.Errorf("key salah: code = %v", code)
+	}
+	if code := status.Code(call("tenant-key", `{"tenant_id":"tenant-b"}`)); code != codes.PermissionDenied {
+		t.Errorf("tenant lain: code = %v", code)
+	}
+	if err := call("admin-key", `{"name":"budi"}`); err != nil {
+		t.Errorf("admin key: %v", err)
+	}
+}
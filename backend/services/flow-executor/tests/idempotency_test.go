@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/delivery"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+const idemFlow = `{
+  "flow_id": "idem-demo",
+  "nodes": [{"id": "reply", "hoop": "StaticReply", "parameters": {"message": "tenant {{tenant_id}}"}}]
+}`
+
+func TestIdempotencyKeyScopedPerTenantAndBody(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	writeExampleFlow(t, "idem-demo.json", idemFlow)
+
+	keys := []delivery.APIKey{{Key: "key-a", Tenant: "tenant_a"}, {Key: "key-b", Tenant: "tenant_b"}}
+	handler := delivery.APIKeyAuth(keys, http.HandlerFunc(delivery.HandleRunFlow))
+
+	post := func(apiKey, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/run-flow/idem-demo.json", strings.NewReader(body))
+		req.Header.Set("X-API-Key", apiKey)
+		req.Header.Set("Idempotency-Key", "same-key")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("key-a", `{"message": "hi"}`); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "tenant tenant_a") {
+		t.Fatalf("tenant_a: %d %s", rec.Code, rec.Body)
+	}
+
+	// Key sama dari tenant lain: dijalankan sendiri, bukan replay response tenant_a
+	rec := post("key-b", `{"message": "hi"}`)
+	if rec.Header().Get("Idempotent-Replayed") != "" || !strings.Contains(rec.Body.String(), "tenant tenant_b") {
+		t.Errorf("tenant_b me-replay response tenant lain: %s", rec.Body)
+	}
+
+	if rec := post("key-a", `{"message": "hi"}`); rec.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("retry tenant_a tidak di-replay: %d %s", rec.Code, rec.Body)
+	}
+	if rec := post("key-a", `{"message": "beda"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("body berbeda: status %d, want 422", rec.Code)
+	}
+}
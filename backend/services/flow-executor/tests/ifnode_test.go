@@ -0,0 +1,21 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+)
+
+func TestIfNodeRejectsMapWithEquals(t *testing.T) {
+	node := executor.Node{ID: "check_menu", Hoop: "IfNode", InputFrom: "show_menu", TruePath: "yes", FalsePath: "no"}
+	input := map[string]interface{}{"field": "menu", "operator": "==", "value": "Dummy menu"}
+	outputs := map[string]map[string]interface{}{
+		"show_menu": {"menu": map[string]interface{}{"items": []interface{}{"nasi", "teh"}}},
+	}
+
+	next, err := executor.ExecuteIfNode(executor.FlowSpec{}, node, input, outputs)
+	if !errors.Is(err, executor.ErrUncomparable) {
+		t.Fatalf("ExecuteIfNode = %q, %v; want ErrUncomparable", next, err)
+	}
+}
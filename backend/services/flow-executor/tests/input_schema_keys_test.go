@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/delivery"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+const schemaFlow = `{
+  "flow_id": "schema-demo",
+  "input_schema": {"required": ["name"], "properties": {"name": {"type": "string"}}},
+  "context": {"outputs": {}},
+  "nodes": [
+    {"id": "greet", "hoop": "StaticReply", "parameters": {"message": "halo {{name}}"}}
+  ]
+}`
+
+func TestRunFlowRejectsKeysOutsideInputSchema(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	writeExampleFlow(t, "schema-demo.json", schemaFlow)
+
+	cases := []struct {
+		body string
+		want int
+	}{
+		{`{"name":"budi"}`, http.StatusOK},
+		{`{"name":"budi","tenant_id":"tenant-a"}`, http.StatusOK},
+		{`{"name":"budi","nmae":"typo"}`, http.StatusBadRequest},
+		{`{"name":"budi","input":{"extra":1}}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/run-flow/schema-demo.json", strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		delivery.HandleRunFlow(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("body %s: status = %d, want %d (%s)", tc.body, rec.Code, tc.want, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/kafkautil"
+)
+
+func TestKafkaAuthInvalidConfigFails(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"mechanism tidak dikenal", map[string]string{"KAFKA_SASL_MECHANISM": "GSSAPI"}},
+		{"CA file tidak ada", map[string]string{"KAFKA_TLS_ENABLED": "true", "KAFKA_TLS_CA_FILE": "/tidak/ada/ca.pem"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			if _, err := kafkautil.Dialer(); err == nil {
+				t.Error("Dialer harus error, bukan fallback ke plaintext")
+			}
+			if _, err := kafkautil.Transport(); err == nil {
+				t.Error("Transport harus error, bukan fallback ke plaintext")
+			}
+		})
+	}
+
+	if _, err := kafkautil.Dialer(); err != nil {
+		t.Errorf("tanpa SASL/TLS harus plaintext tanpa error: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testFlowsDir adalah FLOWS_DIR untuk seluruh package test; loader membaca FLOWS_DIR sekali
+// (sync.Once), jadi di-set di TestMain sebelum test mana pun resolve flow by name
+var testFlowsDir string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "flow-executor-tests")
+	if err != nil {
+		panic(err)
+	}
+	testFlowsDir = dir
+	os.Setenv("FLOWS_DIR", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// writeExampleFlow menulis flow ke flows/examples supaya bisa dijalankan lewat /run-flow/{name}
+func writeExampleFlow(t *testing.T, name, flowJSON string) {
+	t.Helper()
+	dir := filepath.Join(testFlowsDir, "examples")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(flowJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/milkyhoop/flow-executor/internal/observer"
+)
+
+func TestRegisterMetricsIsIdempotent(t *testing.T) {
+	// Registry default: dulu MustRegister panic di panggilan kedua
+	observer.RegisterMetrics()
+	observer.RegisterMetrics()
+
+	reg := prometheus.NewRegistry()
+	for i := 0; i < 2; i++ {
+		if err := observer.RegisterMetricsWith(reg); err != nil {
+			t.Fatalf("RegisterMetricsWith #%d: %v", i+1, err)
+		}
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, f := range families {
+		if f.GetName() == "rag_cache_hits_total" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("metric RAG tidak terdaftar di registry terisolasi")
+	}
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+)
+
+func TestCircuitBreakerCountsOnlyTransientFailures(t *testing.T) {
+	t.Setenv("RAG_BREAKER_FAILURE_THRESHOLD", "2")
+	t.Setenv("RAG_BREAKER_COOLDOWN", "1h")
+	cb := ragclient.NewCircuitBreaker("test")
+
+	// Error aplikasi & cancel dari caller tidak boleh membuka sirkuit
+	for _, err := range []error{
+		status.Error(codes.InvalidArgument, "bad query"),
+		status.Error(codes.NotFound, "no doc"),
+		status.Error(codes.Canceled, "client cancel"),
+		context.Canceled,
+		status.Error(codes.InvalidArgument, "bad query"),
+	} {
+		callErr := err
+		if got := cb.Execute(func() error { return callErr }); errors.Is(got, ragclient.ErrRAGUnavailable) {
+			t.Fatalf("sirkuit terbuka karena %v", err)
+		}
+	}
+
+	// Unavailable lalu cancel (netral) lalu DeadlineExceeded → 2 kegagalan berturut-turut
+	cb.Execute(func() error { return status.Error(codes.Unavailable, "down") })
+	cb.Execute(func() error { return context.Canceled })
+	cb.Execute(func() error { return status.Error(codes.DeadlineExceeded, "slow") })
+
+	called := false
+	err := cb.Execute(func() error { called = true; return nil })
+	if !errors.Is(err, ragclient.ErrRAGUnavailable) || called {
+		t.Fatalf("sirkuit harus terbuka setelah 2 kegagalan transient, err=%v called=%v", err, called)
+	}
+}
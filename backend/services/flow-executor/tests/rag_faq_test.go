@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	ragcrud_pb "github.com/milkyhoop/flow-executor/internal/proto/ragcrud"
+	"github.com/milkyhoop/flow-executor/internal/ragclient"
+	"github.com/milkyhoop/flow-executor/internal/ragclient/mock"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Flow FAQ satu node: rag_search_faq langsung ke ragcrud (di test: mock bufconn)
+const faqFlow = `{
+  "flow_id": "faq-mock",
+  "context": {"outputs": {}},
+  "nodes": [
+    {
+      "id": "search_faq",
+      "hoop": "rag_search_faq",
+      "parameters": {"query": "{{message}}", "tenant_id": "{{tenant_id}}"}
+    }
+  ]
+}`
+
+func TestRagSearchFAQAgainstMock(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+
+	rag, err := mock.Start()
+	if err != nil {
+		t.Fatalf("❌ Gagal start mock RAG: %v", err)
+	}
+	t.Cleanup(rag.Close)
+
+	rag.Crud.AddDocument("tenant_a", "Jam buka", "Toko buka jam 08.00 - 21.00")
+	rag.Crud.AddDocument("tenant_b", "Jam buka", "Tenant lain, tidak boleh bocor")
+
+	path := filepath.Join(t.TempDir(), "faq-mock.json")
+	if err := os.WriteFile(path, []byte(faqFlow), 0644); err != nil {
+		t.Fatalf("❌ Gagal tulis flow: %v", err)
+	}
+
+	input := map[string]interface{}{
+		"message": "jam buka",
+		"input":   map[string]interface{}{"tenant_id": "tenant_a", "user_id": "user_001"},
+	}
+	out, err := executor.RunFlowAndReturnOutputContext(context.Background(), path, input)
+	if err != nil {
+		t.Fatalf("❌ Flow gagal dijalankan: %v", err)
+	}
+
+	if out["answer"] != "Toko buka jam 08.00 - 21.00" {
+		t.Errorf("answer = %v, want dokumen tenant_a", out["answer"])
+	}
+	calls := rag.Crud.SearchCalls()
+	if len(calls) != 1 || calls[0].GetTenantId() != "tenant_a" || calls[0].GetSearchContent() != "jam buka" {
+		t.Errorf("unexpected FuzzySearch calls: %v", calls)
+	}
+}
+
+func TestRagSearchFAQTruncatesLongContent(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	t.Setenv("RAG_MAX_CONTENT_LENGTH", "10")
+
+	rag, err := mock.Start()
+	if err != nil {
+		t.Fatalf("❌ Gagal start mock RAG: %v", err)
+	}
+	t.Cleanup(rag.Close)
+	rag.Crud.AddDocument("tenant_a", "Jam buka", "Toko buka jam 08.00 - 21.00")
+
+	path := filepath.Join(t.TempDir(), "faq-mock.json")
+	if err := os.WriteFile(path, []byte(faqFlow), 0644); err != nil {
+		t.Fatalf("❌ Gagal tulis flow: %v", err)
+	}
+
+	input := map[string]interface{}{"message": "jam buka", "tenant_id": "tenant_a"}
+	out, err := executor.RunFlowAndReturnOutputContext(context.Background(), path, input)
+	if err != nil {
+		t.Fatalf("❌ Flow gagal dijalankan: %v", err)
+	}
+	if out["answer"] != "Toko buka …" || out["truncated"] != true {
+		t.Errorf("output = %v, want jawaban dipotong 10 karakter + marker", out)
+	}
+}
+
+func TestRagSearchFAQRoutesMappedTenant(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+
+	rag, err := mock.Start()
+	if err != nil {
+		t.Fatalf("❌ Gagal start mock RAG: %v", err)
+	}
+	t.Cleanup(rag.Close)
+	rag.Crud.AddDocument("enterprise_a", "Jam buka", "Jawaban dari endpoint default")
+
+	// Endpoint terisolasi untuk enterprise_a, di TCP supaya dial lewat pool ragclient
+	isolated := mock.NewCrudServer()
+	isolated.AddDocument("enterprise_a", "Jam buka", "Jawaban dari endpoint enterprise")
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	ragcrud_pb.RegisterRagCrudServiceServer(srv, isolated)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	ragclient.SetTenantRoutes(map[string]ragclient.TenantRoute{"enterprise_a": {RagCrud: lis.Addr().String()}})
+	t.Cleanup(func() { ragclient.SetTenantRoutes(nil) })
+
+	path := filepath.Join(t.TempDir(), "faq-mock.json")
+	if err := os.WriteFile(path, []byte(faqFlow), 0644); err != nil {
+		t.Fatalf("❌ Gagal tulis flow: %v", err)
+	}
+
+	input := map[string]interface{}{"message": "jam buka", "tenant_id": "enterprise_a"}
+	out, err := executor.RunFlowAndReturnOutputContext(context.Background(), path, input)
+	if err != nil {
+		t.Fatalf("❌ Flow gagal dijalankan: %v", err)
+	}
+	if out["answer"] != "Jawaban dari endpoint enterprise" {
+		t.Errorf("answer = %v, want jawaban endpoint tenant", out["answer"])
+	}
+	if calls := rag.Crud.SearchCalls(); len(calls) != 0 {
+		t.Errorf("endpoint default tidak boleh dipanggil: %v", calls)
+	}
+}
+
+func TestRagSearchFAQTuningPrecedence(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+
+	rag, err := mock.Start()
+	if err != nil {
+		t.Fatalf("❌ Gagal start mock RAG: %v", err)
+	}
+	t.Cleanup(rag.Close)
+
+	// Default tenant: threshold 0.5; node tanpa parameter → pakai tenant
+	executor.SetTenantProvider(func(ctx context.Context, tenantID string) (*executor.TenantConfig, error) {
+		return &executor.TenantConfig{ID: tenantID, Alias: tenantID, RagSimilarityThreshold: 0.5}, nil
+	})
+	t.Cleanup(func() { executor.SetTenantProvider(nil) })
+
+	dir := t.TempDir()
+	run := func(flowJSON string) {
+		t.Helper()
+		path := filepath.Join(dir, "faq-tuning.json")
+		if err := os.WriteFile(path, []byte(flowJSON), 0644); err != nil {
+			t.Fatalf("❌ Gagal tulis flow: %v", err)
+		}
+		input := map[string]interface{}{"message": "jam buka", "tenant_id": "tenant_tuning"}
+		if _, err := executor.RunFlowAndReturnOutputContext(context.Background(), path, input); err != nil {
+			t.Fatalf("❌ Flow gagal dijalankan: %v", err)
+		}
+	}
+
+	run(faqFlow)
+	run(strings.Replace(faqFlow, `"tenant_id": "{{tenant_id}}"`, `"tenant_id": "{{tenant_id}}", "similarity_threshold": 0.9`, 1))
+
+	calls := rag.Crud.SearchCalls()
+	if len(calls) != 2 {
+		t.Fatalf("FuzzySearch calls = %d, want 2", len(calls))
+	}
+	if got := calls[0].GetSimilarityThreshold(); got != 0.5 {
+		t.Errorf("tanpa parameter node: threshold = %v, want default tenant 0.5", got)
+	}
+	if got := calls[1].GetSimilarityThreshold(); got != float32(0.9) {
+		t.Errorf("parameter node: threshold = %v, want 0.9", got)
+	}
+}
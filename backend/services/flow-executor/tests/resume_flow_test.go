@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/statestore"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// memStateStore: StateStore in-memory untuk test resume
+type memStateStore struct {
+	mu     sync.Mutex
+	data   map[string][]byte
+	claims map[string]bool
+}
+
+func newMemStateStore() *memStateStore {
+	return &memStateStore{data: map[string][]byte{}, claims: map[string]bool{}}
+}
+
+func (s *memStateStore) SaveCheckpoint(ctx context.Context, id string, state []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[id] = state
+	return nil
+}
+
+func (s *memStateStore) LoadCheckpoint(ctx context.Context, id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.data[id]
+	if !ok {
+		return nil, statestore.ErrNotFound
+	}
+	return data, nil
+}
+
+func (s *memStateStore) DeleteCheckpoint(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, id)
+	return nil
+}
+
+func (s *memStateStore) ClaimCheckpoint(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claims[id] {
+		return false, nil
+	}
+	s.claims[id] = true
+	return true, nil
+}
+
+func (s *memStateStore) ReleaseCheckpoint(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.claims, id)
+	return nil
+}
+
+// Checkpoint ws-demo milik tenant_a yang berhenti setelah node greet
+const resumeCheckpoint = `{
+  "flow": {
+    "flow_id": "ws-demo",
+    "context": {"tenant_id": "tenant_a", "execution_id": "exec-resume", "outputs": {"greet": {"message": "halo budi"}}},
+    "nodes": [
+      {"id": "greet", "hoop": "StaticReply", "parameters": {"message": "halo {{name}}"}},
+      {"id": "bye", "hoop": "StaticReply", "parameters": {"message": "sampai jumpa"}}
+    ]
+  },
+  "next_node_id": "bye",
+  "step": 1,
+  "outputs": {"greet": {"message": "halo budi"}}
+}`
+
+func TestResumeFlowScopesTenantAndClaimsCheckpoint(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	store := newMemStateStore()
+	executor.SetStateStore(store)
+	t.Cleanup(func() { executor.SetStateStore(nil) })
+	store.data["exec-resume"] = []byte(resumeCheckpoint)
+	ctx := context.Background()
+
+	if _, err := executor.ResumeFlow(ctx, "exec-resume", "tenant_b"); !errors.Is(err, statestore.ErrNotFound) {
+		t.Errorf("resume dari tenant lain: err = %v, want ErrNotFound", err)
+	}
+
+	store.claims["exec-resume"] = true
+	if _, err := executor.ResumeFlow(ctx, "exec-resume", "tenant_a"); !errors.Is(err, executor.ErrResumeInProgress) {
+		t.Errorf("resume paralel: err = %v, want ErrResumeInProgress", err)
+	}
+	delete(store.claims, "exec-resume")
+
+	result, err := executor.ResumeFlow(ctx, "exec-resume", "tenant_a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["message"] != "sampai jumpa" {
+		t.Errorf("result = %v", result)
+	}
+	if _, ok := store.data["exec-resume"]; ok {
+		t.Error("checkpoint seharusnya dihapus setelah flow selesai")
+	}
+	if store.claims["exec-resume"] {
+		t.Error("lock resume tidak dilepas")
+	}
+}
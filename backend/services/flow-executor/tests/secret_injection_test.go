@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/delivery"
+	"github.com/milkyhoop/flow-executor/internal/executor"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+// Caller menyelundupkan {{secret.*}} lewat input, lalu node B me-render ulang output node A via input_from
+const secretInjectionFlow = `{
+  "flow_id": "secret-injection",
+  "context": {"outputs": {}},
+  "nodes": [
+    {"id": "a", "hoop": "StaticReply", "parameters": {"message": "{{message}}"}, "true_path": "b"},
+    {"id": "b", "hoop": "StaticReply", "input_from": "a"}
+  ]
+}`
+
+const secretParamFlow = `{
+  "flow_id": "secret-param",
+  "context": {"outputs": {}},
+  "nodes": [
+    {"id": "reply", "hoop": "StaticReply", "parameters": {"message": "token {{secret.API_KEY}}"}}
+  ]
+}`
+
+func TestSecretPlaceholderFromCallerInputIsNotResolved(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	t.Setenv("SECRET_API_KEY", "s3cr3t-value")
+	writeExampleFlow(t, "secret-injection.json", secretInjectionFlow)
+
+	out, err := executor.RunFlowAndReturnOutput(filepath.Join(testFlowsDir, "examples", "secret-injection.json"), map[string]interface{}{
+		"message": "{{secret.API_KEY}}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["message"] != "{{secret.API_KEY}}" {
+		t.Errorf("message = %v, placeholder secret dari caller seharusnya tetap literal", out["message"])
+	}
+}
+
+func TestRunFlowResponseRedactsSecrets(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	t.Setenv("SECRET_API_KEY", "s3cr3t-value")
+	writeExampleFlow(t, "secret-param.json", secretParamFlow)
+
+	req := httptest.NewRequest(http.MethodPost, "/run-flow/secret-param.json", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	delivery.HandleRunFlow(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); strings.Contains(body, "s3cr3t-value") {
+		t.Errorf("secret bocor di response HTTP: %s", body)
+	}
+}
@@ -0,0 +1,24 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/executor"
+)
+
+func TestRenderTemplateEnvOnlyReadsFlowEnvPrefix(t *testing.T) {
+	t.Setenv("FLOW_ENV_REGION", "id-jkt")
+	t.Setenv("KAFKA_SASL_PASSWORD", "rahasia")
+
+	out := executor.RenderTemplate(map[string]interface{}{
+		"region": "{{env.REGION}}",
+		"leak":   "{{env.KAFKA_SASL_PASSWORD}}",
+	}, map[string]interface{}{})
+
+	if out["region"] != "id-jkt" {
+		t.Errorf("region = %v, want FLOW_ENV_REGION", out["region"])
+	}
+	if out["leak"] != "" {
+		t.Errorf("env di luar prefix FLOW_ENV_ terbaca: %v", out["leak"])
+	}
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/milkyhoop/flow-executor/internal/delivery"
+	"github.com/milkyhoop/flow-executor/internal/tracing"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+}
+
+func TestRunFlowExportsSpansToOTLP(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	writeExampleFlow(t, "trace-demo.json", wsFlow)
+
+	var mu sync.Mutex
+	spans := map[string]exportedSpan{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("path collector = %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rs := range body.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					spans[s.Name] = s
+				}
+			}
+		}
+	}))
+	defer collector.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	tracing.InitFromEnv()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/run-flow/trace-demo.json", strings.NewReader(`{"name":"budi"}`))
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	delivery.HandleRunFlow(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	tracing.Shutdown(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	root, flow := spans["run-flow trace-demo.json"], spans["flow ws-demo"]
+	greet, bye := spans["node greet"], spans["node bye"]
+	if root.SpanID == "" || flow.SpanID == "" || greet.SpanID == "" || bye.SpanID == "" {
+		t.Fatalf("span kurang: %+v", spans)
+	}
+	if root.TraceID != traceID || root.ParentSpanID != "00f067aa0ba902b7" {
+		t.Errorf("root span tidak melanjutkan traceparent: %+v", root)
+	}
+	if flow.ParentSpanID != root.SpanID || greet.ParentSpanID != flow.SpanID || bye.ParentSpanID != flow.SpanID {
+		t.Errorf("parent span salah: root=%+v flow=%+v greet=%+v bye=%+v", root, flow, greet, bye)
+	}
+	for _, s := range []exportedSpan{flow, greet, bye} {
+		if s.TraceID != traceID {
+			t.Errorf("span %s trace_id = %s", s.Name, s.TraceID)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/milkyhoop/flow-executor/internal/delivery"
+	"github.com/milkyhoop/flow-executor/internal/utils"
+)
+
+const wsFlow = `{
+  "flow_id": "ws-demo",
+  "context": {"outputs": {}},
+  "nodes": [
+    {"id": "greet", "hoop": "StaticReply", "parameters": {"message": "halo {{name}}"}},
+    {"id": "bye", "hoop": "StaticReply", "parameters": {"message": "sampai jumpa"}}
+  ]
+}`
+
+type wsTestFrame struct {
+	Type   string                 `json:"type"`
+	NodeID string                 `json:"node_id"`
+	Output map[string]interface{} `json:"output"`
+	Result map[string]interface{} `json:"result"`
+}
+
+func TestRunFlowWebSocketStreamsNodes(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+
+	writeExampleFlow(t, "ws-demo.json", wsFlow)
+	t.Setenv("WS_ALLOWED_ORIGINS", "https://app.milkyhoop.com")
+
+	srv := httptest.NewServer(delivery.HandleRunFlowWS)
+	defer srv.Close()
+
+	ws, err := websocket.Dial(wsURL(srv, "/ws/run-flow/ws-demo.json"), "", "https://app.milkyhoop.com")
+	if err != nil {
+		t.Fatalf("❌ Gagal dial WebSocket: %v", err)
+	}
+	defer ws.Close()
+
+	if err := websocket.JSON.Send(ws, map[string]interface{}{"name": "budi"}); err != nil {
+		t.Fatal(err)
+	}
+
+	frames := readWSFrames(t, ws)
+	if len(frames) != 3 || frames[0].NodeID != "greet" || frames[1].NodeID != "bye" {
+		t.Fatalf("unexpected frames: %+v", frames)
+	}
+	if frames[0].Output["message"] != "halo budi" {
+		t.Errorf("greet output = %v", frames[0].Output)
+	}
+	if last := frames[2]; last.Type != "completed" || last.Result["message"] != "sampai jumpa" {
+		t.Errorf("final frame = %+v", last)
+	}
+}
+
+func wsURL(srv *httptest.Server, path string) string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + path
+}
+
+func readWSFrames(t *testing.T, ws *websocket.Conn) []wsTestFrame {
+	t.Helper()
+	var frames []wsTestFrame
+	for {
+		var f wsTestFrame
+		if err := websocket.JSON.Receive(ws, &f); err != nil {
+			t.Fatalf("❌ Gagal baca frame: %v (frames: %+v)", err, frames)
+		}
+		frames = append(frames, f)
+		if f.Type != "node" {
+			return frames
+		}
+	}
+}
+
+func TestRunFlowWebSocketRejectsUnknownOrigin(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	t.Setenv("WS_ALLOWED_ORIGINS", "https://app.milkyhoop.com")
+
+	srv := httptest.NewServer(delivery.HandleRunFlowWS)
+	defer srv.Close()
+
+	if ws, err := websocket.Dial(wsURL(srv, "/ws/run-flow/ws-demo.json"), "", "https://evil.example"); err == nil {
+		ws.Close()
+		t.Fatal("origin di luar allowlist seharusnya ditolak")
+	}
+}
+
+func TestRunFlowWebSocketAcceptsKeyViaSubprotocolAndQuery(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	writeExampleFlow(t, "ws-demo.json", wsFlow)
+	t.Setenv("WS_ALLOWED_ORIGINS", "*")
+
+	keys := []delivery.APIKey{{Key: "ws-key-123456", Tenant: "tenant_a"}}
+	srv := httptest.NewServer(delivery.APIKeyAuth(keys, delivery.HandleRunFlowWS))
+	defer srv.Close()
+	path := "/ws/run-flow/ws-demo.json"
+
+	if ws, err := websocket.Dial(wsURL(srv, path), "", srv.URL); err == nil {
+		ws.Close()
+		t.Fatal("tanpa API key seharusnya ditolak")
+	}
+
+	cfg, _ := websocket.NewConfig(wsURL(srv, path), srv.URL)
+	cfg.Protocol = []string{"api-key.ws-key-123456"}
+	ws, err := websocket.DialConfig(cfg)
+	if err != nil {
+		t.Fatalf("❌ Gagal dial dengan subprotocol: %v", err)
+	}
+	websocket.JSON.Send(ws, map[string]interface{}{"name": "budi"})
+	if frames := readWSFrames(t, ws); frames[len(frames)-1].Type != "completed" {
+		t.Errorf("final frame = %+v", frames[len(frames)-1])
+	}
+	ws.Close()
+
+	ws, err = websocket.Dial(wsURL(srv, path+"?api_key=ws-key-123456"), "", srv.URL)
+	if err != nil {
+		t.Fatalf("❌ Gagal dial dengan query api_key: %v", err)
+	}
+	ws.Close()
+}
+
+func TestRunFlowWebSocketRedactsFrames(t *testing.T) {
+	utils.InitLogger("flow-executor-test")
+	writeExampleFlow(t, "ws-demo.json", wsFlow)
+	t.Setenv("WS_ALLOWED_ORIGINS", "*")
+	utils.RegisterSecret("rahasia-ws-9876")
+
+	srv := httptest.NewServer(delivery.HandleRunFlowWS)
+	defer srv.Close()
+
+	ws, err := websocket.Dial(wsURL(srv, "/ws/run-flow/ws-demo.json"), "", srv.URL)
+	if err != nil {
+		t.Fatalf("❌ Gagal dial WebSocket: %v", err)
+	}
+	defer ws.Close()
+
+	websocket.JSON.Send(ws, map[string]interface{}{"name": "rahasia-ws-9876"})
+	frames := readWSFrames(t, ws)
+	if msg, _ := frames[0].Output["message"].(string); strings.Contains(msg, "rahasia-ws-9876") || msg == "" {
+		t.Errorf("secret bocor di frame node: %q", msg)
+	}
+}
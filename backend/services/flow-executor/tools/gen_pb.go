@@ -3,8 +3,10 @@ package main
 import (
 	"log"
 	"os"
+	"path/filepath"
 
 	"google.golang.org/protobuf/proto"
+	"github.com/milkyhoop/flow-executor/internal/loader"
 	"github.com/milkyhoop/flow-executor/internal/proto/flow" // ✅ hasil generate
 )
 
@@ -24,8 +26,9 @@ func main() {
 		log.Fatalf("❌ Failed to marshal flow: %v", err)
 	}
 
-	const outPath = "flows/compiled/sample_flow.pb"
-	if err := os.MkdirAll("flows/compiled", 0755); err != nil {
+	// Ikut FLOWS_DIR supaya sama dengan path yang dibaca /run-from-pb
+	outPath := filepath.Join(loader.CompiledDir(), "sample_flow.pb")
+	if err := os.MkdirAll(loader.CompiledDir(), 0755); err != nil {
 		log.Fatalf("❌ Failed to create output directory: %v", err)
 	}
 
@@ -19,7 +19,7 @@ func main() {
 	// 📈 Start Prometheus metrics server
 	go monitoring.StartMetricsServer()
 
-	// 🚀 Start gRPC compiler server
+	// 🚀 Start gRPC server: VisualhoopCompiler (compile flow) + InventoryService (stok)
 	go func() {
 		port := os.Getenv("PORT")
 		if port == "" {
@@ -1,13 +1,14 @@
 module milkyhoop/backend/services/visualhoop-compiler
 
-
 go 1.22.7
 
 toolchain go1.23.8
 
 require (
+	github.com/milkyhoop/grpcmw v0.0.0
 	github.com/prometheus/client_golang v1.22.0
 	github.com/rs/zerolog v1.34.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a
 	google.golang.org/grpc v1.69.0-dev
 	google.golang.org/protobuf v1.36.5
 )
@@ -24,5 +25,6 @@ require (
 	golang.org/x/net v0.35.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	golang.org/x/text v0.22.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
 )
+
+replace github.com/milkyhoop/grpcmw => ../../pkg/grpcmw
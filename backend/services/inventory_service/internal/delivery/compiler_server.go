@@ -9,15 +9,23 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/milkyhoop/grpcmw"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"milkyhoop/backend/services/visualhoop-compiler/internal/inventory"
 	pb "milkyhoop/backend/services/visualhoop-compiler/internal/proto"
+	invpb "milkyhoop/backend/services/visualhoop-compiler/internal/proto/inventory"
 )
 
+// CurrentSchemaVersion adalah versi skema flow yang di-stamp ke setiap .pb hasil compile.
+// Naikkan bila struktur Flow berubah secara tidak kompatibel, dan tambahkan migrasi di executor.
+const CurrentSchemaVersion = 2
+
 var jsonBasePath string
 
 func init() {
@@ -27,6 +35,11 @@ func init() {
 	}
 }
 
+// resolveJSONPath menggabungkan base path dengan path JSON relatif dari client
+func resolveJSONPath(jsonPath string) string {
+	return filepath.Join(jsonBasePath, jsonPath)
+}
+
 type CompilerServer struct {
 	pb.UnimplementedVisualhoopCompilerServer
 }
@@ -35,7 +48,7 @@ func (s *CompilerServer) CompileJsonToPb(ctx context.Context, req *pb.CompileReq
 	log.Info().Msg("🔧 Received CompileJsonToPb request")
 
 	// Gabungkan base path dengan path JSON yang dikirim client
-	fullJsonPath := filepath.Join(jsonBasePath, req.GetJsonPath())
+	fullJsonPath := resolveJSONPath(req.GetJsonPath())
 
 	// Baca file JSON dari full path
 	jsonData, err := ioutil.ReadFile(fullJsonPath)
@@ -44,6 +57,42 @@ func (s *CompilerServer) CompileJsonToPb(ctx context.Context, req *pb.CompileReq
 		return nil, fmt.Errorf("failed to read JSON file '%s': %w", fullJsonPath, err)
 	}
 
+	pbData, err := compileFlow(jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Simpan binary .pb ke path output yang diminta
+	if err := ioutil.WriteFile(req.GetOutputPath(), pbData, 0644); err != nil {
+		log.Error().Err(err).Msg("❌ Failed to write .pb file")
+		return nil, fmt.Errorf("failed to write .pb file: %w", err)
+	}
+
+	log.Info().Str("output", req.GetOutputPath()).Msg("✅ .pb file generated successfully")
+	return &pb.CompileResponse{Message: "Compile success!"}, nil
+}
+
+// CompileBytes menerima JSON inline dan mengembalikan bytes .pb langsung, tanpa shared volume.
+func (s *CompilerServer) CompileBytes(ctx context.Context, req *pb.CompileBytesRequest) (*pb.CompileBytesResponse, error) {
+	log.Info().Int("size", len(req.GetJson())).Msg("🔧 Received CompileBytes request")
+
+	pbData, err := compileFlow(req.GetJson())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Int("size", len(pbData)).Msg("✅ Flow compiled in-memory")
+	return &pb.CompileBytesResponse{Pb: pbData}, nil
+}
+
+// compileFlow memvalidasi JSON flow lalu marshal ke binary .pb
+func compileFlow(jsonData []byte) ([]byte, error) {
+	// Validasi struktur flow sebelum marshal, supaya flow rusak gagal saat build, bukan runtime
+	if err := validateFlowJSON(jsonData); err != nil {
+		log.Error().Err(err).Msg("❌ Invalid flow structure")
+		return nil, err
+	}
+
 	// Unmarshal JSON ke struct proto Flow
 	var flow pb.Flow
 	if err := json.Unmarshal(jsonData, &flow); err != nil {
@@ -51,38 +100,77 @@ func (s *CompilerServer) CompileJsonToPb(ctx context.Context, req *pb.CompileReq
 		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
+	// Stamp versi skema supaya executor bisa migrasi / menolak artefak yang tidak kompatibel
+	flow.SchemaVersion = CurrentSchemaVersion
+
 	// Marshal struct proto ke binary .pb
 	pbData, err := proto.Marshal(&flow)
 	if err != nil {
 		log.Error().Err(err).Msg("❌ Failed to marshal proto")
 		return nil, fmt.Errorf("failed to marshal proto: %w", err)
 	}
+	return pbData, nil
+}
 
-	// Simpan binary .pb ke path output yang diminta
-	if err := ioutil.WriteFile(req.GetOutputPath(), pbData, 0644); err != nil {
-		log.Error().Err(err).Msg("❌ Failed to write .pb file")
-		return nil, fmt.Errorf("failed to write .pb file: %w", err)
+// DecompilePbToJson membaca file .pb hasil compile dan menulis ulang sebagai JSON (pretty-printed),
+// untuk memverifikasi isi artefak yang benar-benar ter-compile.
+func (s *CompilerServer) DecompilePbToJson(ctx context.Context, req *pb.DecompileRequest) (*pb.DecompileResponse, error) {
+	log.Info().Msg("🔍 Received DecompilePbToJson request")
+
+	pbData, err := ioutil.ReadFile(req.GetPbPath())
+	if err != nil {
+		log.Error().Err(err).Str("path", req.GetPbPath()).Msg("❌ Failed to read .pb file")
+		return nil, fmt.Errorf("failed to read .pb file '%s': %w", req.GetPbPath(), err)
 	}
 
-	log.Info().Str("output", req.GetOutputPath()).Msg("✅ .pb file generated successfully")
-	return &pb.CompileResponse{Message: "Compile success!"}, nil
+	var flow pb.Flow
+	if err := proto.Unmarshal(pbData, &flow); err != nil {
+		log.Error().Err(err).Msg("❌ Failed to unmarshal .pb to Flow")
+		return nil, fmt.Errorf("failed to unmarshal .pb: %w", err)
+	}
+
+	// Pakai nama field proto (snake_case) supaya JSON hasil decompile bisa di-compile ulang
+	jsonData, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", UseProtoNames: true}.Marshal(&flow)
+	if err != nil {
+		log.Error().Err(err).Msg("❌ Failed to marshal Flow to JSON")
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	fullJsonPath := resolveJSONPath(req.GetOutputPath())
+	if err := ioutil.WriteFile(fullJsonPath, jsonData, 0644); err != nil {
+		log.Error().Err(err).Str("path", fullJsonPath).Msg("❌ Failed to write JSON file")
+		return nil, fmt.Errorf("failed to write JSON file '%s': %w", fullJsonPath, err)
+	}
+
+	log.Info().Str("output", fullJsonPath).Msg("✅ JSON file decompiled successfully")
+	return &pb.DecompileResponse{Message: "Decompile success!"}, nil
 }
 
-// RunCompilerServer menjalankan gRPC server dan health check
+// RunCompilerServer menjalankan gRPC server: VisualhoopCompiler + InventoryService + health check
 func RunCompilerServer(port string) error {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpcmw.UnaryServerInterceptors(log.Logger))
 
 	// Register server compiler
 	pb.RegisterVisualhoopCompilerServer(grpcServer, &CompilerServer{})
 
+	// Register inventory (stok in-memory, seed dari INVENTORY_SEED)
+	store, err := inventory.NewStoreFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to init inventory store: %w", err)
+	}
+	invpb.RegisterInventoryServiceServer(grpcServer, NewInventoryServer(store))
+
 	// Register health check service
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 
+	// Reflection untuk debugging via grpcurl (opt-in lewat GRPC_REFLECTION)
+	grpcmw.RegisterReflection(grpcServer, log.Logger)
+
 	log.Info().Msgf("🚀 Visualhoop-compiler server running on port %s", port)
 	return grpcServer.Serve(lis)
 }
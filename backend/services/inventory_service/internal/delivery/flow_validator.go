@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flowNode adalah bentuk minimal node flow yang perlu divalidasi sebelum compile
+type flowNode struct {
+	ID        string `json:"id"`
+	Hoop      string `json:"hoop"`
+	InputFrom string `json:"input_from"`
+	TruePath  string `json:"true_path"`
+	FalsePath string `json:"false_path"`
+	JumpTo    string `json:"jump_to"`
+}
+
+type flowDocument struct {
+	Nodes *[]flowNode `json:"nodes"`
+}
+
+// validateFlowJSON memeriksa struktur node: id & hoop wajib, id unik, dan
+// referensi input_from / true_path / false_path / jump_to harus menunjuk node yang ada.
+// Error dikembalikan sebagai gRPC InvalidArgument dengan detail BadRequest per field.
+func validateFlowJSON(jsonData []byte) error {
+	var doc flowDocument
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid flow JSON: %v", err)
+	}
+
+	// Flow tanpa key "nodes" (mis. intent/entities) tidak punya graph untuk divalidasi
+	if doc.Nodes == nil {
+		return nil
+	}
+	nodes := *doc.Nodes
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	violate := func(field, desc string) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: desc})
+	}
+
+	if len(nodes) == 0 {
+		violate("nodes", "flow must contain at least one node")
+	}
+
+	ids := make(map[string]int, len(nodes))
+	for i, n := range nodes {
+		field := fmt.Sprintf("nodes[%d]", i)
+		if n.ID == "" {
+			violate(field+".id", "id must not be empty")
+		} else if first, dup := ids[n.ID]; dup {
+			violate(field+".id", fmt.Sprintf("duplicate id %q (first defined at nodes[%d])", n.ID, first))
+		} else {
+			ids[n.ID] = i
+		}
+		if n.Hoop == "" {
+			violate(field+".hoop", "hoop must not be empty")
+		}
+	}
+
+	for i, n := range nodes {
+		refs := []struct{ name, target string }{
+			{"input_from", n.InputFrom},
+			{"true_path", n.TruePath},
+			{"false_path", n.FalsePath},
+			{"jump_to", n.JumpTo},
+		}
+		for _, ref := range refs {
+			if ref.target == "" {
+				continue
+			}
+			if _, ok := ids[ref.target]; !ok {
+				violate(fmt.Sprintf("nodes[%d].%s", i, ref.name), fmt.Sprintf("references unknown node %q", ref.target))
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	descs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		descs = append(descs, v.Field+": "+v.Description)
+	}
+	st := status.New(codes.InvalidArgument, "invalid flow: "+strings.Join(descs, "; "))
+	if withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
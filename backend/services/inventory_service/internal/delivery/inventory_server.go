@@ -0,0 +1,74 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"milkyhoop/backend/services/visualhoop-compiler/internal/inventory"
+	invpb "milkyhoop/backend/services/visualhoop-compiler/internal/proto/inventory"
+)
+
+// InventoryServer melayani InventoryService (stok), terpisah dari CompilerServer
+// yang melayani VisualhoopCompiler; keduanya hanya berbagi port gRPC yang sama.
+type InventoryServer struct {
+	invpb.UnimplementedInventoryServiceServer
+	store *inventory.Store
+}
+
+func NewInventoryServer(store *inventory.Store) *InventoryServer {
+	return &InventoryServer{store: store}
+}
+
+func (s *InventoryServer) CheckStock(ctx context.Context, req *invpb.CheckStockRequest) (*invpb.StockResponse, error) {
+	if req.GetSku() == "" {
+		return nil, status.Error(codes.InvalidArgument, "sku wajib diisi")
+	}
+	stock, err := s.store.Check(req.GetSku())
+	if err != nil {
+		return nil, stockError(err)
+	}
+	return toStockResponse(stock), nil
+}
+
+func (s *InventoryServer) ReserveStock(ctx context.Context, req *invpb.ReserveStockRequest) (*invpb.StockResponse, error) {
+	if req.GetSku() == "" || req.GetQuantity() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "sku dan quantity > 0 wajib diisi")
+	}
+	stock, err := s.store.Reserve(req.GetSku(), req.GetQuantity())
+	if err != nil {
+		return nil, stockError(err)
+	}
+	log.Info().Str("sku", stock.SKU).Int32("quantity", req.GetQuantity()).Int32("available", stock.Available).Msg("📦 Stock reserved")
+	return toStockResponse(stock), nil
+}
+
+func (s *InventoryServer) ReleaseStock(ctx context.Context, req *invpb.ReleaseStockRequest) (*invpb.StockResponse, error) {
+	if req.GetSku() == "" || req.GetQuantity() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "sku dan quantity > 0 wajib diisi")
+	}
+	stock, err := s.store.Release(req.GetSku(), req.GetQuantity())
+	if err != nil {
+		return nil, stockError(err)
+	}
+	log.Info().Str("sku", stock.SKU).Int32("quantity", req.GetQuantity()).Int32("available", stock.Available).Msg("📦 Stock released")
+	return toStockResponse(stock), nil
+}
+
+// stockError memetakan error store ke gRPC status
+func stockError(err error) error {
+	switch {
+	case errors.Is(err, inventory.ErrUnknownSKU):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, inventory.ErrInsufficientStock):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func toStockResponse(stock inventory.Stock) *invpb.StockResponse {
+	return &invpb.StockResponse{Sku: stock.SKU, Available: stock.Available, Reserved: stock.Reserved}
+}
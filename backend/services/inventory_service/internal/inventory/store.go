@@ -0,0 +1,108 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrUnknownSKU: SKU belum pernah di-seed ke store
+	ErrUnknownSKU = errors.New("unknown sku")
+	// ErrInsufficientStock: reserve melebihi available, atau release melebihi reserved
+	ErrInsufficientStock = errors.New("insufficient stock")
+)
+
+// Stock adalah posisi stok satu SKU
+type Stock struct {
+	SKU       string
+	Available int32
+	Reserved  int32
+}
+
+// Store adalah penyimpanan stok in-memory (awal; nanti bisa diganti DB)
+type Store struct {
+	mu    sync.Mutex
+	items map[string]*Stock
+}
+
+func NewStore() *Store {
+	return &Store{items: map[string]*Stock{}}
+}
+
+// NewStoreFromEnv membuat store dan seed dari ENV INVENTORY_SEED, format "sku:qty,sku:qty"
+func NewStoreFromEnv() (*Store, error) {
+	s := NewStore()
+	seed := strings.TrimSpace(os.Getenv("INVENTORY_SEED"))
+	if seed == "" {
+		return s, nil
+	}
+	for _, entry := range strings.Split(seed, ",") {
+		sku, qty, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || sku == "" {
+			return nil, fmt.Errorf("invalid INVENTORY_SEED entry %q (format sku:qty)", entry)
+		}
+		n, err := strconv.ParseInt(qty, 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid INVENTORY_SEED quantity for %s: %q", sku, qty)
+		}
+		s.SetStock(sku, int32(n))
+	}
+	return s, nil
+}
+
+// SetStock mengisi / menimpa stok available untuk SKU
+func (s *Store) SetStock(sku string, available int32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if item, ok := s.items[sku]; ok {
+		item.Available = available
+		return
+	}
+	s.items[sku] = &Stock{SKU: sku, Available: available}
+}
+
+func (s *Store) Check(sku string) (Stock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[sku]
+	if !ok {
+		return Stock{}, fmt.Errorf("%w: %s", ErrUnknownSKU, sku)
+	}
+	return *item, nil
+}
+
+// Reserve memindahkan qty dari available ke reserved secara atomik
+func (s *Store) Reserve(sku string, qty int32) (Stock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[sku]
+	if !ok {
+		return Stock{}, fmt.Errorf("%w: %s", ErrUnknownSKU, sku)
+	}
+	if qty > item.Available {
+		return *item, fmt.Errorf("%w: %s available %d, requested %d", ErrInsufficientStock, sku, item.Available, qty)
+	}
+	item.Available -= qty
+	item.Reserved += qty
+	return *item, nil
+}
+
+// Release mengembalikan qty dari reserved ke available
+func (s *Store) Release(sku string, qty int32) (Stock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[sku]
+	if !ok {
+		return Stock{}, fmt.Errorf("%w: %s", ErrUnknownSKU, sku)
+	}
+	if qty > item.Reserved {
+		return *item, fmt.Errorf("%w: %s reserved %d, release %d", ErrInsufficientStock, sku, item.Reserved, qty)
+	}
+	item.Reserved -= qty
+	item.Available += qty
+	return *item, nil
+}
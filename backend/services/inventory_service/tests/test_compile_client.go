@@ -34,4 +34,15 @@ func main() {
 	}
 
 	log.Printf("✅ Response: %s", resp.GetMessage())
+
+	// Decompile balik ke JSON untuk verifikasi isi .pb
+	decompileResp, err := client.DecompilePbToJson(ctx, &pb.DecompileRequest{
+		PbPath:     req.OutputPath,
+		OutputPath: "backend/services/visualhoop-compiler/tests/testdata/sample_flow.decompiled.json",
+	})
+	if err != nil {
+		log.Fatalf("❌ DecompilePbToJson failed: %v", err)
+	}
+
+	log.Printf("✅ Response: %s", decompileResp.GetMessage())
 }
@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/milkyhoop/notification-service/internal/config"
 	"github.com/milkyhoop/notification-service/internal/delivery"
 	"github.com/milkyhoop/notification-service/internal/observability"
+	"github.com/milkyhoop/notification-service/internal/repo"
+	"github.com/milkyhoop/notification-service/internal/service"
 	"github.com/milkyhoop/notification-service/pkg/logger"
 )
 
@@ -25,6 +30,24 @@ func main() {
 	// Init Prometheus metrics
 	observability.InitMetrics()
 
+	// SASL/TLS Kafka invalid → gagal start, jangan diam-diam plaintext
+	if err := delivery.ValidateKafkaAuth(); err != nil {
+		logger.Log.Fatal().Err(err).Msg("❌ Invalid Kafka SASL/TLS config")
+	}
+
+	// Init audit log notifikasi (Postgres)
+	if dsn := config.DatabaseURL(); dsn != "" {
+		notifRepo, err := repo.NewPostgresNotificationRepository(dsn)
+		if err != nil {
+			logger.Log.Error().Err(err).Msg("❌ Failed to connect notification DB, audit log disabled")
+		} else {
+			defer notifRepo.Close()
+			service.SetNotificationRepository(notifRepo)
+		}
+	} else {
+		logger.Log.Warn().Msg("⚠️ DATABASE_URL not set, notification audit log disabled")
+	}
+
 	// Start Prometheus metrics HTTP server (:8080)
 	delivery.StartMetricsServer()
 
@@ -36,11 +59,29 @@ func main() {
 	go delivery.StartGRPCServer()
 
 	// Jalankan Kafka consumer
-	go delivery.StartKafkaConsumer(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go delivery.StartKafkaConsumer(ctx, &wg)
 
 	// Graceful shutdown
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	<-sig
 	cancel()
+
+	// Tunggu notifikasi in-flight selesai (handle + commit), maksimal drain timeout
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Log.Info().Msg("✅ Kafka consumer drained")
+	case <-time.After(config.ShutdownDrainTimeout()):
+		logger.Log.Warn().
+			Dur("timeout", config.ShutdownDrainTimeout()).
+			Msg("⏱️ Drain timeout exceeded, forcing shutdown")
+	}
 }
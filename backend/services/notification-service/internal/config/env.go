@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // ✅ Default broker (kafka service di Docker Compose)
@@ -23,6 +26,25 @@ func KafkaTopic() string {
 	return topic
 }
 
+// Daftar topic yang dikonsumsi (KAFKA_TOPICS, dipisah koma).
+// Jika kosong, fallback ke single topic dari KafkaTopic().
+func KafkaTopics() []string {
+	raw := os.Getenv("KAFKA_TOPICS")
+	if raw == "" {
+		return []string{KafkaTopic()}
+	}
+
+	var topics []string
+	for _, t := range strings.Split(raw, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			topics = append(topics, t)
+		}
+	}
+	if len(topics) == 0 {
+		return []string{KafkaTopic()}
+	}
+	return topics
+}
 
 func KafkaGroupID() string {
 	groupID := os.Getenv("KAFKA_GROUP_ID")
@@ -31,3 +53,205 @@ func KafkaGroupID() string {
 	}
 	return groupID
 }
+
+// Topik dead-letter untuk notifikasi yang gagal diproses
+func KafkaDLQTopic() string {
+	topic := os.Getenv("KAFKA_DLQ_TOPIC")
+	if topic == "" {
+		topic = "send-notification-dlq"
+	}
+	return topic
+}
+
+// Backoff dasar untuk retry baca Kafka (contoh: "500ms")
+func KafkaRetryBaseBackoff() time.Duration {
+	return durationEnv("KAFKA_RETRY_BASE_BACKOFF", 500*time.Millisecond)
+}
+
+// Batas atas backoff supaya exponential tidak kebablasan
+func KafkaRetryMaxBackoff() time.Duration {
+	return durationEnv("KAFKA_RETRY_MAX_BACKOFF", 30*time.Second)
+}
+
+// Pengali backoff tiap retry
+func KafkaRetryMultiplier() float64 {
+	if v := os.Getenv("KAFKA_RETRY_MULTIPLIER"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 1 {
+			return f
+		}
+	}
+	return 2
+}
+
+// Maksimal retry per pesan
+func KafkaMaxRetries() int {
+	if v := os.Getenv("KAFKA_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 5
+}
+
+// Mekanisme SASL Kafka: PLAIN, SCRAM-SHA-256, SCRAM-SHA-512 (kosong = tanpa auth)
+func KafkaSASLMechanism() string {
+	return strings.ToUpper(os.Getenv("KAFKA_SASL_MECHANISM"))
+}
+
+func KafkaSASLUsername() string {
+	return os.Getenv("KAFKA_SASL_USERNAME")
+}
+
+func KafkaSASLPassword() string {
+	return os.Getenv("KAFKA_SASL_PASSWORD")
+}
+
+// TLS ke broker Kafka (KAFKA_TLS_ENABLED=true)
+func KafkaTLSEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("KAFKA_TLS_ENABLED"))
+	return enabled
+}
+
+// CA bundle opsional untuk verifikasi sertifikat broker
+func KafkaTLSCAFile() string {
+	return os.Getenv("KAFKA_TLS_CA_FILE")
+}
+
+// SMTP untuk channel email
+func SMTPHost() string {
+	return os.Getenv("SMTP_HOST")
+}
+
+func SMTPPort() string {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return port
+}
+
+func SMTPUsername() string {
+	return os.Getenv("SMTP_USERNAME")
+}
+
+func SMTPPassword() string {
+	return os.Getenv("SMTP_PASSWORD")
+}
+
+// Alamat pengirim; default ke SMTP_USERNAME
+func SMTPFrom() string {
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = SMTPUsername()
+	}
+	return from
+}
+
+// Endpoint WhatsApp Business API (contoh: https://graph.facebook.com/v19.0/<phone_id>/messages)
+func WhatsAppAPIURL() string {
+	return os.Getenv("WHATSAPP_API_URL")
+}
+
+// Bearer token untuk WhatsApp Business API
+func WhatsAppAPIToken() string {
+	return os.Getenv("WHATSAPP_API_TOKEN")
+}
+
+// Direktori template notifikasi (<template_id>.tmpl); kosong = pakai template embedded
+func NotificationTemplateDir() string {
+	return os.Getenv("NOTIFICATION_TEMPLATE_DIR")
+}
+
+// DSN Postgres untuk audit log notifikasi; kosong = tidak disimpan
+func DatabaseURL() string {
+	return os.Getenv("DATABASE_URL")
+}
+
+// Window idempotensi: event dengan dedup key sama dalam window ini di-skip
+func DedupWindow() time.Duration {
+	return durationEnv("DEDUP_WINDOW", 10*time.Minute)
+}
+
+// REJECT_UNRENDERED=true → payload dengan placeholder {{...}} tersisa ditolak (retry/DLQ),
+// default hanya warning
+func RejectUnrendered() bool {
+	reject, _ := strconv.ParseBool(os.Getenv("REJECT_UNRENDERED"))
+	return reject
+}
+
+// Rate limit notifikasi per user (token per detik); default 0 = nonaktif, karena flow-executor
+// mengirim satu event per node dan limit kecil diam-diam membuang event flow yang panjang
+func NotificationRateLimit() float64 {
+	if v := os.Getenv("NOTIFICATION_RATE_LIMIT"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+			return f
+		}
+	}
+	return 0
+}
+
+// Burst maksimal notifikasi per user
+func NotificationRateBurst() int {
+	if v := os.Getenv("NOTIFICATION_RATE_BURST"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 10
+}
+
+// URL webhook per tenant: WEBHOOK_URL_<TENANT_ID>. WEBHOOK_URL global hanya dipakai jika
+// WEBHOOK_GLOBAL_FALLBACK=true, supaya event tenant tanpa config tidak terkirim ke endpoint lain.
+func WebhookURL(tenantID string) (string, error) {
+	return tenantEnv("WEBHOOK_URL", tenantID)
+}
+
+// Secret HMAC webhook per tenant: WEBHOOK_SECRET_<TENANT_ID>, fallback global sama seperti WebhookURL
+func WebhookSecret(tenantID string) (string, error) {
+	return tenantEnv("WEBHOOK_SECRET", tenantID)
+}
+
+// WEBHOOK_GLOBAL_FALLBACK=true → tenant tanpa config per tenant memakai WEBHOOK_URL/WEBHOOK_SECRET
+// (opt-in, hanya untuk deployment single-tenant)
+func WebhookGlobalFallback() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("WEBHOOK_GLOBAL_FALLBACK"))
+	return enabled
+}
+
+func WebhookTimeout() time.Duration {
+	return durationEnv("WEBHOOK_TIMEOUT", 10*time.Second)
+}
+
+// tenantEnv membaca <prefix>_<TENANT_ID uppercase>; fallback ke <prefix> hanya jika WebhookGlobalFallback.
+// Tenant ID hanya boleh huruf kecil, angka, dan _ supaya pemetaan ke nama env satu-satu
+// (acme-co vs acme_co, Acme vs acme tidak boleh membaca env yang sama).
+func tenantEnv(prefix, tenantID string) (string, error) {
+	if tenantID != "" {
+		for _, r := range tenantID {
+			if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '_' {
+				return "", fmt.Errorf("tenant id %q cannot be mapped to %s_<TENANT_ID> (allowed: a-z, 0-9, _)", tenantID, prefix)
+			}
+		}
+		if v := os.Getenv(prefix + "_" + strings.ToUpper(tenantID)); v != "" {
+			return v, nil
+		}
+	}
+	if WebhookGlobalFallback() {
+		return os.Getenv(prefix), nil
+	}
+	return "", nil
+}
+
+// Batas waktu menunggu pesan in-flight selesai saat shutdown
+func ShutdownDrainTimeout() time.Duration {
+	return durationEnv("SHUTDOWN_DRAIN_TIMEOUT", 10*time.Second)
+}
+
+func durationEnv(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return def
+}
@@ -0,0 +1,36 @@
+package delivery
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+)
+
+// backoff menghitung jeda retry: exponential, di-cap, dengan full jitter.
+// Dibuat baru untuk tiap pesan supaya state retry tidak bocor antar pesan.
+type backoff struct {
+	base       time.Duration
+	max        time.Duration
+	multiplier float64
+	attempt    int
+}
+
+func newBackoff() *backoff {
+	return &backoff{
+		base:       config.KafkaRetryBaseBackoff(),
+		max:        config.KafkaRetryMaxBackoff(),
+		multiplier: config.KafkaRetryMultiplier(),
+	}
+}
+
+// Next mengembalikan jeda untuk retry berikutnya.
+func (b *backoff) Next() time.Duration {
+	ceiling := float64(b.base) * math.Pow(b.multiplier, float64(b.attempt))
+	if ceiling > float64(b.max) {
+		ceiling = float64(b.max)
+	}
+	b.attempt++
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
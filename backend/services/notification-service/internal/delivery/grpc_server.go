@@ -6,7 +6,9 @@ import (
 	"log"
 	"net"
 
+	"github.com/milkyhoop/grpcmw"
 	pb "github.com/milkyhoop/notification-service/internal/delivery/pb/notification"
+	"github.com/milkyhoop/notification-service/pkg/logger"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
@@ -31,7 +33,7 @@ func StartGRPCServer() {
 		log.Fatalf("❌ Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpcmw.UnaryServerInterceptors(logger.Log))
 
 	// ✅ Register Notification Service
 	pb.RegisterNotificationServiceServer(grpcServer, &NotificationHandler{})
@@ -40,6 +42,9 @@ func StartGRPCServer() {
 	healthSvc := InitHealthCheck()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthSvc)
 
+	// Reflection untuk debugging via grpcurl (opt-in lewat GRPC_REFLECTION)
+	grpcmw.RegisterReflection(grpcServer, logger.Log)
+
 	fmt.Println("✅ gRPC NotificationService running on :5005")
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("❌ Failed to serve: %v", err)
@@ -0,0 +1,98 @@
+package delivery
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// kafkaDialer dipakai kafka.ReaderConfig; SASL/TLS aktif sesuai env, plaintext jika tidak diset.
+// Config SASL/TLS yang invalid dikembalikan sebagai error, bukan fallback ke plaintext.
+func kafkaDialer() (*kafka.Dialer, error) {
+	mechanism, tlsCfg, err := kafkaAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		SASLMechanism: mechanism,
+		TLS:           tlsCfg,
+	}, nil
+}
+
+// kafkaTransport dipakai kafka.Writer (DLQ) dengan konfigurasi auth yang sama.
+func kafkaTransport() (*kafka.Transport, error) {
+	mechanism, tlsCfg, err := kafkaAuth()
+	if err != nil {
+		return nil, err
+	}
+	return &kafka.Transport{
+		SASL: mechanism,
+		TLS:  tlsCfg,
+	}, nil
+}
+
+func kafkaAuth() (sasl.Mechanism, *tls.Config, error) {
+	mechanism, err := saslMechanism()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Kafka SASL config: %w", err)
+	}
+	tlsCfg, err := tlsConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid Kafka TLS config: %w", err)
+	}
+	return mechanism, tlsCfg, nil
+}
+
+// ValidateKafkaAuth dipanggil saat startup: config SASL/TLS invalid harus menggagalkan start
+func ValidateKafkaAuth() error {
+	_, _, err := kafkaAuth()
+	return err
+}
+
+func saslMechanism() (sasl.Mechanism, error) {
+	username := config.KafkaSASLUsername()
+	password := config.KafkaSASLPassword()
+
+	switch config.KafkaSASLMechanism() {
+	case "":
+		return nil, nil
+	case "PLAIN":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, username, password)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("unsupported KAFKA_SASL_MECHANISM: %s", config.KafkaSASLMechanism())
+	}
+}
+
+func tlsConfig() (*tls.Config, error) {
+	if !config.KafkaTLSEnabled() {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile := config.KafkaTLSCAFile(); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read KAFKA_TLS_CA_FILE: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates in %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
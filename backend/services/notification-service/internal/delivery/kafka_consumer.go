@@ -2,6 +2,8 @@ package delivery
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/milkyhoop/notification-service/internal/config"
@@ -11,22 +13,54 @@ import (
 	"github.com/segmentio/kafka-go"
 )
 
-func StartKafkaConsumer(ctx context.Context) {
+// StartKafkaConsumer menjalankan satu reader per topic (config.KafkaTopics) sampai ctx dibatalkan.
+// Pesan yang sedang diproses tetap diselesaikan (handle + commit) sebelum wg.Done,
+// supaya main bisa drain saat shutdown.
+func StartKafkaConsumer(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	if err := InitDLQWriter(); err != nil {
+		logger.Log.Error().Err(err).Msg("❌ Failed to init DLQ writer, Kafka consumer not started")
+		return
+	}
+	defer CloseDLQWriter()
+
+	var readers sync.WaitGroup
+	for _, topic := range config.KafkaTopics() {
+		readers.Add(1)
+		go func(topic string) {
+			defer readers.Done()
+			consumeTopic(ctx, topic)
+		}(topic)
+	}
+	readers.Wait()
+}
+
+func consumeTopic(ctx context.Context, topic string) {
+	dialer, err := kafkaDialer()
+	if err != nil {
+		logger.Log.Error().Err(err).Str("topic", topic).Msg("❌ Invalid Kafka auth config, consumer not started")
+		return
+	}
+
+	// CommitInterval 0 → commit sinkron, hanya lewat CommitMessages (manual)
 	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: config.KafkaBrokers(),
-		Topic:   config.KafkaTopic(),
-		GroupID: config.KafkaGroupID(),
+		Brokers:        config.KafkaBrokers(),
+		Topic:          topic,
+		GroupID:        config.KafkaGroupID(),
+		CommitInterval: 0,
+		Dialer:         dialer,
 	})
 	defer reader.Close()
 
 	logger.Log.Info().
-		Str("topic", config.KafkaTopic()).
+		Str("topic", topic).
 		Msg("🔄 Listening to Kafka topic")
 
 	for {
 		select {
 		case <-ctx.Done():
-			logger.Log.Warn().Msg("🛑 Kafka consumer context cancelled")
+			logger.Log.Warn().Str("topic", topic).Msg("🛑 Kafka consumer context cancelled")
 			return
 		default:
 			handleKafkaMessage(ctx, reader)
@@ -34,41 +68,133 @@ func StartKafkaConsumer(ctx context.Context) {
 	}
 }
 
-func handleKafkaMessage(ctx context.Context, reader *kafka.Reader) {
-	retryCount := 0
-	for {
-		m, err := reader.ReadMessage(ctx)
-		if err != nil {
-			logger.Log.Warn().
-				Int("retry", retryCount+1).
-				Err(err).
-				Msg("⚠️ Kafka read error")
-			retryCount++
-			if retryCount >= 5 {
-				logger.Log.Error().Msg("🚨 Max retries exceeded")
-				return
-			}
-			time.Sleep(time.Duration(retryCount*500) * time.Millisecond)
-			continue
-		}
+// MessageReader adalah subset *kafka.Reader yang dipakai consumer (supaya bisa di-mock saat test)
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
 
-		ctxWithIDs := logger.InjectIDs(ctx)
+// HandleNotificationFunc memproses payload notifikasi; bisa diganti saat test
+var HandleNotificationFunc = service.HandleNotification
 
-		observability.KafkaMessagesConsumed.
-			WithLabelValues(config.KafkaTopic()).
-			Inc()
+// PublishDLQFunc mengirim pesan gagal ke dead-letter topic; bisa diganti saat test
+var PublishDLQFunc = publishToDLQ
 
+// handleKafkaMessage membaca dan memproses tepat satu pesan.
+// Retry + backoff hanya berlaku untuk pesan ini.
+func handleKafkaMessage(ctx context.Context, reader MessageReader) {
+	if err := ConsumeMessage(ctx, reader); err != nil && ctx.Err() == nil {
+		logger.Log.Error().Err(err).Msg("🚨 Failed to consume Kafka message")
+	}
+}
+
+// ConsumeMessage fetch satu pesan, proses, lalu commit offset (at-least-once).
+// Offset hanya di-commit jika HandleNotification sukses atau pesan berhasil masuk DLQ.
+// Jika DLQ gagal, publish di-retry (partition tertahan) sampai berhasil; pesan berikutnya
+// tidak boleh di-commit duluan karena commit offset-nya ikut melewati pesan ini.
+// Hanya saat shutdown pesan dilepas tanpa commit dan akan dibaca ulang setelah restart.
+func ConsumeMessage(ctx context.Context, reader MessageReader) error {
+	m, err := readMessageWithRetry(ctx, reader)
+	if err != nil {
+		return err
+	}
+
+	// Pesan sudah di-fetch: selesaikan proses + commit walau ctx dibatalkan (graceful drain)
+	shutdown := ctx
+	ctx = context.WithoutCancel(ctx)
+	ctxWithIDs := logger.InjectIDsFromHeaders(ctx, headersToMap(m.Headers))
+
+	observability.KafkaMessagesConsumed.
+		WithLabelValues(m.Topic).
+		Inc()
+
+	logger.WithContext(ctxWithIDs).
+		Str("payload", string(m.Value)).
+		Msg("📨 Kafka received")
+
+	// 🧠 Proses payload secara modular
+	if err := HandleNotificationFunc(ctxWithIDs, m.Topic, m.Value); err != nil {
 		logger.WithContext(ctxWithIDs).
-			Str("payload", string(m.Value)).
-			Msg("📨 Kafka received")
-
-		// 🧠 Proses payload secara modular
-		if err := service.HandleNotification(m.Value); err != nil {
-			logger.WithContext(ctxWithIDs).
-				Err(err).
-				Msg("❌ Failed to process notification")
+			Err(err).
+			Msg("❌ Failed to process notification")
+
+		// 🪦 Jangan buang diam-diam, kirim ke dead-letter topic
+		if dlqErr := publishToDLQWithRetry(shutdown, ctx, m, err); dlqErr != nil {
+			// Shutdown: tidak di-commit → pesan akan dibaca ulang setelah restart
+			return fmt.Errorf("failed to dead-letter notification: %w", dlqErr)
 		}
+		logger.WithContext(ctxWithIDs).
+			Str("dlq_topic", config.KafkaDLQTopic()).
+			Msg("🪦 Notification sent to DLQ")
+	}
 
-		return
+	if err := reader.CommitMessages(ctx, m); err != nil {
+		return fmt.Errorf("failed to commit offset %d: %w", m.Offset, err)
+	}
+	return nil
+}
+
+// publishToDLQWithRetry mengirim ke DLQ dengan capped exponential backoff tanpa batas retry,
+// sehingga consumer topic ini tertahan di pesan yang sama selama DLQ down.
+// Berhenti hanya jika shutdown dibatalkan; pesan lalu dibiarkan tidak di-commit.
+func publishToDLQWithRetry(shutdown, ctx context.Context, m kafka.Message, procErr error) error {
+	bo := newBackoff()
+	for retry := 1; ; retry++ {
+		err := PublishDLQFunc(ctx, m, procErr)
+		if err == nil {
+			return nil
+		}
+
+		logger.Log.Warn().
+			Int("retry", retry).
+			Str("topic", m.Topic).
+			Int64("offset", m.Offset).
+			Err(err).
+			Msg("⚠️ DLQ publish failed, partition blocked until it succeeds")
+
+		select {
+		case <-shutdown.Done():
+			return err
+		case <-time.After(bo.Next()):
+		}
+	}
+}
+
+// readMessageWithRetry mencoba FetchMessage dengan capped exponential backoff + jitter.
+func readMessageWithRetry(ctx context.Context, reader MessageReader) (kafka.Message, error) {
+	maxRetries := config.KafkaMaxRetries()
+	bo := newBackoff()
+
+	for retry := 1; ; retry++ {
+		m, err := reader.FetchMessage(ctx)
+		if err == nil {
+			return m, nil
+		}
+		if ctx.Err() != nil {
+			return kafka.Message{}, ctx.Err()
+		}
+
+		logger.Log.Warn().
+			Int("retry", retry).
+			Err(err).
+			Msg("⚠️ Kafka read error")
+
+		if retry >= maxRetries {
+			return kafka.Message{}, fmt.Errorf("kafka read failed after %d retries: %w", retry, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		case <-time.After(bo.Next()):
+		}
+	}
+}
+
+func headersToMap(headers []kafka.Header) map[string]string {
+	out := make(map[string]string, len(headers))
+	for _, h := range headers {
+		out[h.Key] = string(h.Value)
 	}
+	return out
 }
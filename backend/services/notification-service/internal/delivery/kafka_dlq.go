@@ -0,0 +1,72 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+	"github.com/milkyhoop/notification-service/internal/observability"
+	"github.com/milkyhoop/notification-service/pkg/logger"
+	"github.com/segmentio/kafka-go"
+)
+
+var dlqWriter *kafka.Writer
+
+// InitDLQWriter menyiapkan writer Kafka untuk topik dead-letter
+func InitDLQWriter() error {
+	transport, err := kafkaTransport()
+	if err != nil {
+		return err
+	}
+	dlqWriter = &kafka.Writer{
+		Addr:      kafka.TCP(config.KafkaBrokers()...),
+		Topic:     config.KafkaDLQTopic(),
+		Balancer:  &kafka.LeastBytes{},
+		Transport: transport,
+	}
+
+	logger.Log.Info().
+		Str("topic", config.KafkaDLQTopic()).
+		Msg("🪦 DLQ writer ready")
+	return nil
+}
+
+// CloseDLQWriter menutup writer DLQ (dipanggil saat shutdown)
+func CloseDLQWriter() {
+	if dlqWriter == nil {
+		return
+	}
+	if err := dlqWriter.Close(); err != nil {
+		logger.Log.Error().Err(err).Msg("❌ Failed to close DLQ writer")
+	}
+}
+
+// publishToDLQ mengirim pesan asli + error ke topik dead-letter.
+// Payload dibiarkan utuh; info error dan asal pesan dikirim lewat header.
+func publishToDLQ(ctx context.Context, m kafka.Message, procErr error) error {
+	if dlqWriter == nil {
+		return fmt.Errorf("dlq writer not initialized")
+	}
+
+	headers := append([]kafka.Header{}, m.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: "dlq-error", Value: []byte(procErr.Error())},
+		kafka.Header{Key: "dlq-original-topic", Value: []byte(m.Topic)},
+		kafka.Header{Key: "dlq-original-partition", Value: []byte(fmt.Sprintf("%d", m.Partition))},
+		kafka.Header{Key: "dlq-original-offset", Value: []byte(fmt.Sprintf("%d", m.Offset))},
+	)
+
+	err := dlqWriter.WriteMessages(ctx, kafka.Message{
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish to DLQ: %w", err)
+	}
+
+	observability.KafkaMessagesDeadLettered.
+		WithLabelValues(m.Topic).
+		Inc()
+	return nil
+}
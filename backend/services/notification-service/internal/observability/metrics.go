@@ -12,6 +12,50 @@ var KafkaMessagesConsumed = prometheus.NewCounterVec(
 	[]string{"topic"},
 )
 
+var KafkaMessagesDeadLettered = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "kafka_messages_dead_lettered_total",
+		Help: "Total Kafka messages published to the dead-letter topic by source topic",
+	},
+	[]string{"topic"},
+)
+
+var NotificationsSent = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "notifications_sent_total",
+		Help: "Total notifications delivered by channel",
+	},
+	[]string{"channel"},
+)
+
+var NotificationsFailed = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "notifications_failed_total",
+		Help: "Total notification delivery failures by channel",
+	},
+	[]string{"channel"},
+)
+
+var NotificationsDeduplicated = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "notifications_deduplicated_total",
+		Help: "Total duplicate notifications skipped by idempotency check",
+	},
+)
+
+var NotificationsRateLimited = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "notifications_rate_limited_total",
+		Help: "Total notifications dropped by per-user rate limiting",
+	},
+	[]string{"user_id"},
+)
+
 func InitMetrics() {
 	prometheus.MustRegister(KafkaMessagesConsumed)
+	prometheus.MustRegister(KafkaMessagesDeadLettered)
+	prometheus.MustRegister(NotificationsSent)
+	prometheus.MustRegister(NotificationsFailed)
+	prometheus.MustRegister(NotificationsDeduplicated)
+	prometheus.MustRegister(NotificationsRateLimited)
 }
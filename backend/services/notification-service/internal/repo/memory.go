@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryNotificationRepository menyimpan notifikasi di memori (untuk test / dev tanpa DB)
+type InMemoryNotificationRepository struct {
+	mu    sync.Mutex
+	items []Notification
+}
+
+func NewInMemoryNotificationRepository() *InMemoryNotificationRepository {
+	return &InMemoryNotificationRepository{}
+}
+
+func (r *InMemoryNotificationRepository) Save(ctx context.Context, n *Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = append(r.items, *n)
+	return nil
+}
+
+// List mengembalikan salinan semua notifikasi yang tersimpan
+func (r *InMemoryNotificationRepository) List() []Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Notification(nil), r.items...)
+}
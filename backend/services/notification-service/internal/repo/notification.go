@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"context"
+	"time"
+)
+
+// Status notifikasi yang disimpan
+const (
+	StatusSent        = "sent"
+	StatusFailed      = "failed"
+	StatusSkipped     = "skipped"
+	StatusRateLimited = "rate_limited"
+)
+
+// Notification adalah satu baris audit log notifikasi yang sudah diproses
+type Notification struct {
+	TenantID    string
+	UserID      string
+	Channel     string
+	Status      string
+	Error       string
+	Payload     []byte
+	TraceID     string
+	ReceivedAt  time.Time
+	ProcessedAt time.Time
+}
+
+// NotificationRepository menyimpan notifikasi yang sudah diproses
+type NotificationRepository interface {
+	Save(ctx context.Context, n *Notification) error
+}
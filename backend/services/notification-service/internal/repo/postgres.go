@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+)
+
+// PostgresNotificationRepository menulis ke tabel notifications_log
+// (lihat backend/migrations/V078__notifications_log.sql)
+type PostgresNotificationRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresNotificationRepository(dsn string) (*PostgresNotificationRepository, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping postgres: %w", err)
+	}
+	return &PostgresNotificationRepository{db: db}, nil
+}
+
+func (r *PostgresNotificationRepository) Save(ctx context.Context, n *Notification) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO notifications_log
+			(tenant_id, user_id, channel, status, error, payload, trace_id, received_at, processed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		nullIfEmpty(n.TenantID),
+		nullIfEmpty(n.UserID),
+		n.Channel,
+		n.Status,
+		nullIfEmpty(n.Error),
+		n.Payload,
+		nullIfEmpty(n.TraceID),
+		n.ReceivedAt,
+		n.ProcessedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert notification: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresNotificationRepository) Close() error {
+	return r.db.Close()
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
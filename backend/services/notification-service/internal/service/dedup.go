@@ -0,0 +1,69 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+)
+
+// dedupStore menyimpan dedup key yang sudah diproses selama window tertentu (in-memory).
+type dedupStore struct {
+	mu     sync.Mutex
+	window time.Duration
+	seen   map[string]time.Time
+	lastGC time.Time
+}
+
+var (
+	dedup     *dedupStore
+	dedupOnce sync.Once
+)
+
+func getDedupStore() *dedupStore {
+	dedupOnce.Do(func() {
+		dedup = &dedupStore{
+			window: config.DedupWindow(),
+			seen:   make(map[string]time.Time),
+		}
+	})
+	return dedup
+}
+
+// dedupKey memakai message_id dari payload, atau hash payload jika tidak ada.
+func dedupKey(payload map[string]interface{}, raw []byte) string {
+	if id, ok := payload["message_id"].(string); ok && id != "" {
+		return "id:" + id
+	}
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Seen true jika key sudah diproses dalam window.
+func (d *dedupStore) Seen(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	at, ok := d.seen[key]
+	return ok && time.Since(at) < d.window
+}
+
+// Mark mencatat key sebagai sudah diproses; entry kadaluarsa dibersihkan berkala.
+func (d *dedupStore) Mark(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	d.seen[key] = now
+
+	if now.Sub(d.lastGC) > d.window {
+		for k, at := range d.seen {
+			if now.Sub(at) >= d.window {
+				delete(d.seen, k)
+			}
+		}
+		d.lastGC = now
+	}
+}
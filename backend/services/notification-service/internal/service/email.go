@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+)
+
+// EmailNotifier mengirim payload channel "email" via SMTP.
+type EmailNotifier struct{}
+
+func (e *EmailNotifier) Channel() string { return "email" }
+
+// Send memvalidasi field wajib (to, subject, body) lalu kirim via SMTP.
+// Error dikembalikan supaya retry/DLQ jalan.
+func (e *EmailNotifier) Send(payload map[string]interface{}) error {
+	to, _ := payload["to"].(string)
+	subject, _ := payload["subject"].(string)
+	body, _ := payload["body"].(string)
+
+	var missing []string
+	if to == "" {
+		missing = append(missing, "to")
+	}
+	if subject == "" {
+		missing = append(missing, "subject")
+	}
+	if body == "" {
+		missing = append(missing, "body")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("email payload missing required fields: %s", strings.Join(missing, ", "))
+	}
+
+	host := config.SMTPHost()
+	if host == "" {
+		return fmt.Errorf("SMTP_HOST not configured")
+	}
+	addr := net.JoinHostPort(host, config.SMTPPort())
+
+	var auth smtp.Auth
+	if config.SMTPUsername() != "" {
+		auth = smtp.PlainAuth("", config.SMTPUsername(), config.SMTPPassword(), host)
+	}
+
+	recipients := strings.Split(to, ",")
+	for i := range recipients {
+		recipients[i] = strings.TrimSpace(recipients[i])
+	}
+
+	msg := buildEmailMessage(config.SMTPFrom(), recipients, subject, body)
+	if err := smtp.SendMail(addr, auth, config.SMTPFrom(), recipients, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("📧 Email sent to %s", to)
+	return nil
+}
+
+func buildEmailMessage(from string, to []string, subject, body string) []byte {
+	// Cegah header injection lewat subject
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return []byte(b.String())
+}
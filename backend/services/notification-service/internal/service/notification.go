@@ -1,14 +1,33 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+	"github.com/milkyhoop/notification-service/internal/observability"
+	"github.com/milkyhoop/notification-service/internal/repo"
+	"github.com/milkyhoop/notification-service/pkg/logger"
 )
 
-// HandleNotification adalah entry point modular untuk proses payload notifikasi
-func HandleNotification(raw []byte) error {
-	log.Printf("🔔 [NOTIF] Received payload: %s", string(raw))
+var notificationRepo repo.NotificationRepository
+
+// SetNotificationRepository mengatur storage audit log; nil = tidak disimpan
+func SetNotificationRepository(r repo.NotificationRepository) {
+	notificationRepo = r
+}
+
+// HandleNotification adalah entry point modular untuk proses payload notifikasi.
+// topic asal pesan dipakai untuk menentukan channel (send-email → email, dst).
+func HandleNotification(ctx context.Context, topic string, raw []byte) error {
+	receivedAt := time.Now()
+	log.Printf("🔔 [NOTIF] Received payload from %s: %s", topic, string(raw))
 
 	var payload map[string]interface{}
 	if err := json.Unmarshal(raw, &payload); err != nil {
@@ -16,41 +35,149 @@ func HandleNotification(raw []byte) error {
 		return err
 	}
 
-	// Deteksi apakah masih ada placeholder seperti {{input.message}} di seluruh nilai string
-	placeholderRegex := regexp.MustCompile(`\{\{.*?\}\}`)
-	hasPlaceholder := false
-
-	// Cek recursive
-	var checkPlaceholders func(interface{}) bool
-	checkPlaceholders = func(v interface{}) bool {
-		switch val := v.(type) {
-		case map[string]interface{}:
-			for _, item := range val {
-				if checkPlaceholders(item) {
-					return true
-				}
-			}
-		case []interface{}:
-			for _, item := range val {
-				if checkPlaceholders(item) {
-					return true
-				}
-			}
-		case string:
-			if placeholderRegex.MatchString(val) {
-				return true
-			}
+	// Kafka at-least-once → event yang sama bisa datang dua kali
+	key := dedupKey(payload, raw)
+	if getDedupStore().Seen(key) {
+		log.Printf("♻️ Duplicate notification skipped: %s", key)
+		observability.NotificationsDeduplicated.Inc()
+		return nil
+	}
+
+	// Render template jika payload membawa template_id (+ data); tanpa template_id pakai message mentah
+	if templateID, ok := payload["template_id"].(string); ok && templateID != "" {
+		data, _ := payload["data"].(map[string]interface{})
+		rendered, err := renderTemplate(templateID, data)
+		if err != nil {
+			log.Printf("❌ Gagal render template %s: %v", templateID, err)
+			return err
+		}
+		payload["message"] = rendered
+		if _, ok := payload["body"]; !ok {
+			payload["body"] = rendered
 		}
-		return false
 	}
 
-	hasPlaceholder = checkPlaceholders(payload)
-	if hasPlaceholder {
+	// Deteksi apakah masih ada placeholder seperti {{input.message}} di seluruh nilai string
+	unrendered := findPlaceholders(payload, "")
+	if len(unrendered) > 0 {
+		if config.RejectUnrendered() {
+			err := fmt.Errorf("payload contains unrendered placeholders at: %s", strings.Join(unrendered, ", "))
+			log.Printf("❌ Payload ditolak: %v", err)
+			saveNotification(ctx, payload, raw, ChannelForTopic(topic, payload), repo.StatusFailed, err, receivedAt)
+			return err
+		}
 		log.Printf("⚠️ WARNING: Payload masih mengandung placeholder yang belum dirender: %s", string(raw))
 	} else {
 		log.Printf("✅ Payload siap diproses.")
 	}
 
-	// TODO: parsing lanjut → simpan ke DB, kirim ke WA/email, dll
+	channel := ChannelForTopic(topic, payload)
+
+	// Lindungi user dari flow yang loop / spam; pesan di-drop (tidak di-retry)
+	if limiter := getRateLimiter(); limiter != nil {
+		if userID, _ := payload["user_id"].(string); userID != "" && !limiter.Allow(userID) {
+			log.Printf("🚦 Rate limit exceeded for user %s, notification dropped", userID)
+			observability.NotificationsRateLimited.WithLabelValues(userID).Inc()
+			saveNotification(ctx, payload, raw, channel, repo.StatusRateLimited, nil, receivedAt)
+			return nil
+		}
+	}
+
+	log.Printf("📬 Routing notification to channel: %s", channel)
+
+	handled, err := dispatch(channel, payload)
+	switch {
+	case err != nil:
+		observability.NotificationsFailed.WithLabelValues(channel).Inc()
+		saveNotification(ctx, payload, raw, channel, repo.StatusFailed, err, receivedAt)
+		return err
+	case !handled:
+		// TODO: kirim ke SMS, dll
+		log.Printf("⚠️ No notifier registered for channel: %s", channel)
+		getDedupStore().Mark(key)
+		saveNotification(ctx, payload, raw, channel, repo.StatusSkipped, nil, receivedAt)
+		return nil
+	}
+
+	// Tandai setelah sukses saja, supaya retry setelah gagal tetap diproses
+	getDedupStore().Mark(key)
+	observability.NotificationsSent.WithLabelValues(channel).Inc()
+	saveNotification(ctx, payload, raw, channel, repo.StatusSent, nil, receivedAt)
 	return nil
 }
+
+// saveNotification menulis audit log; gagal simpan hanya di-log supaya tidak memicu kirim ulang
+func saveNotification(ctx context.Context, payload map[string]interface{}, raw []byte, channel, status string, procErr error, receivedAt time.Time) {
+	if notificationRepo == nil {
+		return
+	}
+
+	n := &repo.Notification{
+		Channel:     channel,
+		Status:      status,
+		Payload:     raw,
+		TraceID:     logger.GetTraceID(ctx),
+		ReceivedAt:  receivedAt,
+		ProcessedAt: time.Now(),
+	}
+	n.TenantID, _ = payload["tenant_id"].(string)
+	n.UserID, _ = payload["user_id"].(string)
+	if n.TenantID == "" {
+		n.TenantID = logger.GetTenantID(ctx)
+	}
+	if procErr != nil {
+		n.Error = procErr.Error()
+	}
+
+	if err := notificationRepo.Save(ctx, n); err != nil {
+		log.Printf("❌ Gagal simpan audit log notifikasi: %v", err)
+	}
+}
+
+var placeholderRegex = regexp.MustCompile(`\{\{.*?\}\}`)
+
+// findPlaceholders mengembalikan path (mis. "data.items[0].name") yang nilainya masih berisi {{...}}.
+func findPlaceholders(v interface{}, path string) []string {
+	var found []string
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			found = append(found, findPlaceholders(val[k], child)...)
+		}
+	case []interface{}:
+		for i, item := range val {
+			found = append(found, findPlaceholders(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case string:
+		if placeholderRegex.MatchString(val) {
+			found = append(found, path)
+		}
+	}
+	return found
+}
+
+// ChannelForTopic menentukan channel pengiriman dari nama topic.
+// Topic generik (send-notification) memakai field "channel" di payload.
+func ChannelForTopic(topic string, payload map[string]interface{}) string {
+	switch topic {
+	case "send-email":
+		return "email"
+	case "send-sms":
+		return "sms"
+	case "send-whatsapp":
+		return "whatsapp"
+	}
+	if channel, ok := payload["channel"].(string); ok && channel != "" {
+		return channel
+	}
+	return "default"
+}
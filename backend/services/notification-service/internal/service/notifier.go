@@ -0,0 +1,51 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Notifier adalah channel pengiriman notifikasi (email, whatsapp, sms, ...).
+// Semua channel memakai dispatch path yang sama, dipilih berdasarkan nama channel.
+type Notifier interface {
+	Channel() string
+	Send(payload map[string]interface{}) error
+}
+
+var (
+	notifiersMu sync.RWMutex
+	notifiers   = map[string]Notifier{}
+)
+
+func init() {
+	RegisterNotifier(&EmailNotifier{})
+	RegisterNotifier(NewWhatsAppNotifier())
+	RegisterNotifier(NewWebhookNotifier())
+}
+
+// RegisterNotifier mendaftarkan (atau mengganti) notifier untuk channel-nya
+func RegisterNotifier(n Notifier) {
+	notifiersMu.Lock()
+	defer notifiersMu.Unlock()
+	notifiers[n.Channel()] = n
+}
+
+func getNotifier(channel string) (Notifier, bool) {
+	notifiersMu.RLock()
+	defer notifiersMu.RUnlock()
+	n, ok := notifiers[channel]
+	return n, ok
+}
+
+// dispatch mengirim payload ke notifier sesuai channel.
+// handled=false jika belum ada notifier untuk channel tersebut.
+func dispatch(channel string, payload map[string]interface{}) (handled bool, err error) {
+	n, ok := getNotifier(channel)
+	if !ok {
+		return false, nil
+	}
+	if err := n.Send(payload); err != nil {
+		return true, fmt.Errorf("%s delivery failed: %w", channel, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+)
+
+// userRateLimiter adalah token bucket per user_id.
+type userRateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // token per detik
+	burst   float64
+	buckets map[string]*tokenBucket
+	lastGC  time.Time
+}
+
+type tokenBucket struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+var (
+	rateLimiter     *userRateLimiter
+	rateLimiterOnce sync.Once
+)
+
+// getRateLimiter mengembalikan nil jika rate limiting dimatikan (NOTIFICATION_RATE_LIMIT=0).
+func getRateLimiter() *userRateLimiter {
+	rateLimiterOnce.Do(func() {
+		rate := config.NotificationRateLimit()
+		if rate <= 0 {
+			return
+		}
+		rateLimiter = &userRateLimiter{
+			rate:    rate,
+			burst:   float64(config.NotificationRateBurst()),
+			buckets: make(map[string]*tokenBucket),
+		}
+	})
+	return rateLimiter
+}
+
+// Allow mengambil satu token dari bucket user; false jika bucket kosong.
+func (l *userRateLimiter) Allow(userID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.gcIdle(now)
+	b, ok := l.buckets[userID]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, lastFill: now}
+		l.buckets[userID] = b
+	}
+
+	b.tokens += now.Sub(b.lastFill).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.lastFill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// gcIdle membuang bucket user yang sudah penuh lagi (idle) supaya map tidak tumbuh tanpa batas
+func (l *userRateLimiter) gcIdle(now time.Time) {
+	if now.Sub(l.lastGC) < time.Minute {
+		return
+	}
+	l.lastGC = now
+	idle := time.Duration(l.burst / l.rate * float64(time.Second))
+	for userID, b := range l.buckets {
+		if now.Sub(b.lastFill) > idle {
+			delete(l.buckets, userID)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+)
+
+// Template bawaan; bisa dioverride lewat NOTIFICATION_TEMPLATE_DIR
+//
+//go:embed templates/*.tmpl
+var embeddedTemplates embed.FS
+
+var (
+	templateCacheMu sync.RWMutex
+	templateCache   = map[string]*template.Template{}
+)
+
+// renderTemplate merender template_id dengan data memakai text/template.
+// Urutan lookup: NOTIFICATION_TEMPLATE_DIR/<id>.tmpl lalu template embedded.
+func renderTemplate(templateID string, data map[string]interface{}) (string, error) {
+	tmpl, err := loadTemplate(templateID)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", templateID, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func loadTemplate(templateID string) (*template.Template, error) {
+	// template_id hanya nama, bukan path
+	if templateID == "" || strings.ContainsAny(templateID, `/\`) || strings.Contains(templateID, "..") {
+		return nil, fmt.Errorf("invalid template_id: %q", templateID)
+	}
+
+	templateCacheMu.RLock()
+	tmpl, ok := templateCache[templateID]
+	templateCacheMu.RUnlock()
+	if ok {
+		return tmpl, nil
+	}
+
+	filename := templateID + ".tmpl"
+	var content []byte
+	var err error
+	if dir := config.NotificationTemplateDir(); dir != "" {
+		content, err = os.ReadFile(filepath.Join(dir, filename))
+	}
+	if content == nil {
+		content, err = embeddedTemplates.ReadFile("templates/" + filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("template %s not found: %w", templateID, err)
+	}
+
+	tmpl, err = template.New(templateID).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", templateID, err)
+	}
+
+	templateCacheMu.Lock()
+	templateCache[templateID] = tmpl
+	templateCacheMu.Unlock()
+	return tmpl, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+)
+
+const (
+	webhookSignatureHeader = "X-MilkyHoop-Signature"
+	webhookTimestampHeader = "X-MilkyHoop-Timestamp"
+	webhookMaxRedirects    = 3
+)
+
+// ErrWebhookNotConfigured: tenant tidak punya WEBHOOK_URL_<TENANT_ID>. Permanen (retry tidak
+// akan berhasil sampai config ditambah), pesan langsung ke DLQ.
+var ErrWebhookNotConfigured = errors.New("webhook not configured for tenant")
+
+// WebhookNotifier POST payload JSON ke URL webhook milik tenant, ditandatangani HMAC-SHA256.
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+func NewWebhookNotifier() *WebhookNotifier {
+	return &WebhookNotifier{client: &http.Client{
+		Timeout: config.WebhookTimeout(),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= webhookMaxRedirects {
+				return errors.New("too many webhook redirects")
+			}
+			return nil
+		},
+	}}
+}
+
+func (w *WebhookNotifier) Channel() string { return "webhook" }
+
+// Send mengirim payload ke WEBHOOK_URL tenant. Signature = hex(HMAC-SHA256(secret, timestamp + "." + body)).
+// 5xx / network error dikembalikan sebagai error supaya retry/DLQ jalan; 4xx juga error (permanen).
+func (w *WebhookNotifier) Send(payload map[string]interface{}) error {
+	tenantID, _ := payload["tenant_id"].(string)
+	url, err := config.WebhookURL(tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWebhookNotConfigured, err)
+	}
+	if url == "" {
+		return fmt.Errorf("%w: %q", ErrWebhookNotConfigured, tenantID)
+	}
+	secret, err := config.WebhookSecret(tenantID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWebhookNotConfigured, err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	if secret != "" {
+		req.Header.Set(webhookSignatureHeader, "sha256="+signWebhook(secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	log.Printf("🪝 Webhook %s for tenant %s responded %d", url, tenantID, resp.StatusCode)
+
+	switch {
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("webhook returned %d (retryable)", resp.StatusCode)
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
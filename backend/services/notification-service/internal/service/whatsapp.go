@@ -0,0 +1,70 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+)
+
+// WhatsAppNotifier mengirim payload channel "whatsapp" ke WhatsApp Business API.
+type WhatsAppNotifier struct {
+	client *http.Client
+}
+
+func NewWhatsAppNotifier() *WhatsAppNotifier {
+	return &WhatsAppNotifier{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *WhatsAppNotifier) Channel() string { return "whatsapp" }
+
+// Send memetakan field "to" (nomor telepon) dan "message" ke request body provider.
+// Response non-2xx dikembalikan sebagai error supaya retry/DLQ jalan.
+func (w *WhatsAppNotifier) Send(payload map[string]interface{}) error {
+	to, _ := payload["to"].(string)
+	message, _ := payload["message"].(string)
+	if to == "" || message == "" {
+		return fmt.Errorf("whatsapp payload requires 'to' and 'message'")
+	}
+
+	url := config.WhatsAppAPIURL()
+	if url == "" {
+		return fmt.Errorf("WHATSAPP_API_URL not configured")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"to":                to,
+		"type":              "text",
+		"text":              map[string]string{"body": message},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal whatsapp request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build whatsapp request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+config.WhatsAppAPIToken())
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("whatsapp request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("whatsapp API returned %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	log.Printf("💬 WhatsApp sent to %s (status %d): %s", to, resp.StatusCode, string(respBody))
+	return nil
+}
@@ -10,6 +10,7 @@ type ctxKey string
 const (
 	TraceIDKey   ctxKey = "trace_id"
 	RequestIDKey ctxKey = "request_id"
+	TenantIDKey  ctxKey = "tenant_id"
 )
 
 func InjectIDs(ctx context.Context) context.Context {
@@ -20,6 +21,21 @@ func InjectIDs(ctx context.Context) context.Context {
 	return ctx
 }
 
+// InjectIDsFromHeaders memakai trace_id/tenant_id dari header Kafka (dikirim flow-executor)
+// supaya log bisa dikorelasikan end-to-end. trace_id baru dibuat jika header tidak ada.
+func InjectIDsFromHeaders(ctx context.Context, headers map[string]string) context.Context {
+	traceID := headers["trace_id"]
+	if traceID == "" {
+		traceID = uuid.New().String()
+	}
+	ctx = context.WithValue(ctx, TraceIDKey, traceID)
+	ctx = context.WithValue(ctx, RequestIDKey, uuid.New().String())
+	if tenantID := headers["tenant_id"]; tenantID != "" {
+		ctx = context.WithValue(ctx, TenantIDKey, tenantID)
+	}
+	return ctx
+}
+
 func GetTraceID(ctx context.Context) string {
 	if v, ok := ctx.Value(TraceIDKey).(string); ok {
 		return v
@@ -33,3 +49,10 @@ func GetRequestID(ctx context.Context) string {
 	}
 	return ""
 }
+
+func GetTenantID(ctx context.Context) string {
+	if v, ok := ctx.Value(TenantIDKey).(string); ok {
+		return v
+	}
+	return ""
+}
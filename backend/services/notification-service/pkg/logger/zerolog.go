@@ -20,7 +20,11 @@ func InitLogger() {
 }
 
 func WithContext(ctx context.Context) *zerolog.Event {
-	return Log.Info().
+	event := Log.Info().
 		Str("trace_id", GetTraceID(ctx)).
 		Str("request_id", GetRequestID(ctx))
+	if tenantID := GetTenantID(ctx); tenantID != "" {
+		event = event.Str("tenant_id", tenantID)
+	}
+	return event
 }
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/milkyhoop/notification-service/internal/delivery"
+	"github.com/segmentio/kafka-go"
+)
+
+// fakeReader mensimulasikan consumer group: FetchMessage maju terus,
+// restart() kembali ke offset terakhir yang di-commit.
+type fakeReader struct {
+	messages  []kafka.Message
+	next      int
+	committed int
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if r.next >= len(r.messages) {
+		return kafka.Message{}, context.Canceled
+	}
+	m := r.messages[r.next]
+	r.next++
+	return m, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	for _, m := range msgs {
+		if int(m.Offset)+1 > r.committed {
+			r.committed = int(m.Offset) + 1
+		}
+	}
+	return nil
+}
+
+func (r *fakeReader) restart() {
+	r.next = r.committed
+}
+
+func TestConsumeMessageRereadsAfterHandlerFailure(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{
+		{Topic: "send-notification", Offset: 0, Value: []byte(`{"message":"halo"}`)},
+	}}
+
+	original := delivery.HandleNotificationFunc
+	defer func() { delivery.HandleNotificationFunc = original }()
+
+	calls := 0
+	delivery.HandleNotificationFunc = func(ctx context.Context, topic string, raw []byte) error {
+		calls++
+		if calls == 1 {
+			return errors.New("simulated failure")
+		}
+		return nil
+	}
+
+	// Gagal proses + DLQ tidak tersedia + shutdown → offset tidak boleh di-commit
+	shutdown, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := delivery.ConsumeMessage(shutdown, reader); err == nil {
+		t.Fatal("expected error when handler fails and DLQ is unavailable")
+	}
+	if reader.committed != 0 {
+		t.Fatalf("offset committed after failure: %d", reader.committed)
+	}
+
+	// Restart consumer → pesan yang sama dibaca ulang dan kali ini sukses
+	reader.restart()
+	if err := delivery.ConsumeMessage(context.Background(), reader); err != nil {
+		t.Fatalf("unexpected error on re-read: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected message to be handled twice, got %d", calls)
+	}
+	if reader.committed != 1 {
+		t.Fatalf("expected offset 1 committed, got %d", reader.committed)
+	}
+}
+
+func TestConsumeMessageBlocksUntilDLQPublishSucceeds(t *testing.T) {
+	t.Setenv("KAFKA_RETRY_BASE_BACKOFF", "1ms")
+	t.Setenv("KAFKA_RETRY_MAX_BACKOFF", "5ms")
+
+	reader := &fakeReader{messages: []kafka.Message{
+		{Topic: "send-notification", Offset: 0, Value: []byte(`{"message":"rusak"}`)},
+		{Topic: "send-notification", Offset: 1, Value: []byte(`{"message":"halo"}`)},
+	}}
+
+	originalHandle, originalDLQ := delivery.HandleNotificationFunc, delivery.PublishDLQFunc
+	defer func() {
+		delivery.HandleNotificationFunc, delivery.PublishDLQFunc = originalHandle, originalDLQ
+	}()
+
+	delivery.HandleNotificationFunc = func(ctx context.Context, topic string, raw []byte) error {
+		if string(raw) == `{"message":"rusak"}` {
+			return errors.New("simulated failure")
+		}
+		return nil
+	}
+	dlqAttempts := 0
+	delivery.PublishDLQFunc = func(ctx context.Context, m kafka.Message, procErr error) error {
+		dlqAttempts++
+		if dlqAttempts < 3 {
+			return errors.New("dlq down")
+		}
+		return nil
+	}
+
+	// DLQ gagal 2x → retry di pesan yang sama, baru commit setelah masuk DLQ
+	if err := delivery.ConsumeMessage(context.Background(), reader); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dlqAttempts != 3 {
+		t.Fatalf("expected 3 DLQ attempts, got %d", dlqAttempts)
+	}
+	if reader.committed != 1 || reader.next != 1 {
+		t.Fatalf("pesan berikutnya tidak boleh dibaca sebelum DLQ sukses: committed=%d next=%d", reader.committed, reader.next)
+	}
+
+	if err := delivery.ConsumeMessage(context.Background(), reader); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reader.committed != 2 {
+		t.Fatalf("expected offset 2 committed, got %d", reader.committed)
+	}
+}
@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/milkyhoop/notification-service/internal/repo"
+	"github.com/milkyhoop/notification-service/internal/service"
+)
+
+func TestHandleNotificationPersistsAuditLog(t *testing.T) {
+	memRepo := repo.NewInMemoryNotificationRepository()
+	service.SetNotificationRepository(memRepo)
+	defer service.SetNotificationRepository(nil)
+
+	// Channel tanpa notifier → skipped
+	if err := service.HandleNotification(context.Background(), "send-notification",
+		[]byte(`{"channel":"pager","user_id":"user_001","tenant_id":"tenant_a","message":"halo"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Email tanpa field wajib → failed
+	if err := service.HandleNotification(context.Background(), "send-email",
+		[]byte(`{"user_id":"user_002","subject":"Tes"}`)); err == nil {
+		t.Fatal("expected error for email payload without 'to' and 'body'")
+	}
+
+	saved := memRepo.List()
+	if len(saved) != 2 {
+		t.Fatalf("expected 2 audit records, got %d", len(saved))
+	}
+
+	if saved[0].Status != repo.StatusSkipped || saved[0].Channel != "pager" || saved[0].TenantID != "tenant_a" {
+		t.Errorf("unexpected first record: %+v", saved[0])
+	}
+	if saved[1].Status != repo.StatusFailed || saved[1].Channel != "email" || saved[1].Error == "" {
+		t.Errorf("unexpected second record: %+v", saved[1])
+	}
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/milkyhoop/notification-service/internal/config"
+	"github.com/milkyhoop/notification-service/internal/service"
+)
+
+func TestWebhookURLIsPerTenantWithoutImplicitFallback(t *testing.T) {
+	t.Setenv("WEBHOOK_URL", "https://global.example/hook")
+	t.Setenv("WEBHOOK_URL_TENANT_A", "https://tenant-a.example/hook")
+
+	if url, err := config.WebhookURL("tenant_a"); err != nil || url != "https://tenant-a.example/hook" {
+		t.Errorf("tenant_a: url=%q err=%v", url, err)
+	}
+	if url, err := config.WebhookURL("tenant_b"); err != nil || url != "" {
+		t.Errorf("tenant_b tanpa config tidak boleh jatuh ke WEBHOOK_URL global: url=%q err=%v", url, err)
+	}
+	if _, err := config.WebhookURL("acme-co"); err == nil {
+		t.Error("tenant id dengan karakter ambigu seharusnya ditolak")
+	}
+
+	t.Setenv("WEBHOOK_GLOBAL_FALLBACK", "true")
+	if url, _ := config.WebhookURL("tenant_b"); url != "https://global.example/hook" {
+		t.Errorf("fallback opt-in: url=%q", url)
+	}
+}
+
+func TestWebhookSendWithoutTenantConfigIsNotConfigured(t *testing.T) {
+	t.Setenv("WEBHOOK_URL", "https://global.example/hook")
+
+	err := service.NewWebhookNotifier().Send(map[string]interface{}{"tenant_id": "tenant_b", "message": "halo"})
+	if !errors.Is(err, service.ErrWebhookNotConfigured) {
+		t.Errorf("err = %v, want ErrWebhookNotConfigured", err)
+	}
+}